@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// benchSizes is how many processes each scheduler benchmark is run on: go test -bench . -sizes 100,1000
+var benchSizes = flag.String("sizes", "100,1000,10000", "comma-separated workload sizes for the scheduler benchmarks")
+
+// benchSchedule runs schedule as a sub-benchmark per size in benchSizes, on a workload from GenerateProcesses
+// with a fixed seed so that runs can be compared.
+func benchSchedule(b *testing.B, schedule func([]Process) ScheduleResult) {
+	for _, field := range strings.Split(*benchSizes, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n <= 0 {
+			b.Fatalf("invalid benchmark size %q", field)
+		}
+		processes := GenerateProcesses(n, 1, GenOptions{})
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				schedule(processes)
+			}
+		})
+	}
+}
+
+func BenchmarkFCFS(b *testing.B) {
+	benchSchedule(b, func(processes []Process) ScheduleResult { return firstComeFirstServe(processes, options{}) })
+}
+
+func BenchmarkFCFSMulti(b *testing.B) {
+	benchSchedule(b, func(processes []Process) ScheduleResult { return firstComeFirstServeMulti(processes, 4) })
+}
+
+func BenchmarkSJF(b *testing.B) {
+	benchSchedule(b, func(processes []Process) ScheduleResult { return shortestJobFirst(processes, options{}) })
+}
+
+func BenchmarkSJFPriority(b *testing.B) {
+	benchSchedule(b, sjfPriority)
+}
+
+func BenchmarkSRTF(b *testing.B) {
+	benchSchedule(b, func(processes []Process) ScheduleResult { return shortestRemainingTime(processes, options{}) })
+}
+
+func BenchmarkPriority(b *testing.B) {
+	benchSchedule(b, func(processes []Process) ScheduleResult { return highestPriority(processes, options{}) })
+}
+
+func BenchmarkPreemptivePriority(b *testing.B) {
+	benchSchedule(b, func(processes []Process) ScheduleResult { return preemptivePriority(processes, options{}) })
+}
+
+func BenchmarkHRRN(b *testing.B) {
+	benchSchedule(b, highestResponseRatio)
+}
+
+func BenchmarkEDF(b *testing.B) {
+	benchSchedule(b, earliestDeadline)
+}
+
+func BenchmarkRR(b *testing.B) {
+	benchSchedule(b, func(processes []Process) ScheduleResult { return roundRobin(processes, 4, options{}) })
+}
+
+func BenchmarkMLFQ(b *testing.B) {
+	benchSchedule(b, func(processes []Process) ScheduleResult { return multilevelFeedback(processes, []int64{2, 4, 8}) })
+}
+
+func BenchmarkLottery(b *testing.B) {
+	benchSchedule(b, func(processes []Process) ScheduleResult { return lottery(processes, rand.New(rand.NewSource(1))) })
+}
+
+func BenchmarkWeightedFair(b *testing.B) {
+	benchSchedule(b, weightedFair)
+}
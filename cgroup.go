@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region CPU budgets
+
+// GroupUsage is the CPU time one Cgroup received under BudgetSchedule.
+type GroupUsage struct {
+	Cgroup string `json:"cgroup"`
+	// Budget is the group's CPU time per window, or 0 if it had none.
+	Budget int64 `json:"budget"`
+	// Used is the CPU time the group's processes ran for in all.
+	Used int64 `json:"used"`
+	// OverBudget is the part of Used the group ran for after spending a window's budget,
+	// because nothing within its budget was ready.
+	OverBudget int64 `json:"overBudget"`
+}
+
+// BudgetSchedule outputs and returns a first-come, first-serve schedule in which each Cgroup named in budgets
+// may use that much CPU time per window of time units, like a container CPU limit. Windows start at 0.
+// Once a group has spent its budget, its processes are deprioritized until the next window:
+// they run only when no process within its budget is ready, and the ones that are take the CPU from them.
+// Processes without a Cgroup, or in a group without a budget, are never throttled.
+// Every budget and the window must be greater than 0. The CPU time of each group is reported.
+func BudgetSchedule(w io.Writer, title string, processes []Process, budgets map[string]int64, window int64) ScheduleResult {
+	if window <= 0 {
+		_, _ = fmt.Fprintf(w, "invalid budget window %d: must be greater than 0\n", window)
+		return ScheduleResult{Title: title}
+	}
+	for group, budget := range budgets {
+		if budget <= 0 {
+			_, _ = fmt.Fprintf(w, "invalid budget %d for group %q: must be greater than 0\n", budget, group)
+			return ScheduleResult{Title: title}
+		}
+	}
+	if !schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := budgeted(processes, budgets, window)
+	result.Title = title
+	outputResult(w, result)
+	outputGroupUsage(w, result.GroupUsage)
+
+	return result
+}
+
+// budgeted simulates one time unit at a time, running the first process in arrival order
+// whose group is within budget, or failing that the first that is ready at all.
+func budgeted(processes []Process, budgets map[string]int64, window int64) ScheduleResult {
+	order := arrivalOrder(processes, TieDefault)
+	remaining := make([]int64, len(processes))
+	firstRun := make([]int64, len(processes))
+	for i, p := range processes {
+		remaining[i] = p.BurstDuration
+		firstRun[i] = -1
+	}
+
+	usage := make(map[string]*GroupUsage)
+	for _, p := range processes {
+		if p.Cgroup != "" && usage[p.Cgroup] == nil {
+			usage[p.Cgroup] = &GroupUsage{Cgroup: p.Cgroup, Budget: budgets[p.Cgroup]}
+		}
+	}
+	spent := make(map[string]int64)
+	throttled := func(p Process) bool {
+		budget, ok := budgets[p.Cgroup]
+		return p.Cgroup != "" && ok && spent[p.Cgroup] >= budget
+	}
+
+	var (
+		currentTime int64
+		done        int
+		schedule    = make([]ScheduleRow, len(processes))
+		gantt       = make([]TimeSlice, 0)
+	)
+	for done < len(processes) {
+		if currentTime%window == 0 {
+			clear(spent)
+		}
+
+		next, nextArrival := -1, int64(-1)
+		for _, i := range order {
+			p := processes[i]
+			if remaining[i] == 0 {
+				continue
+			}
+			if p.ArrivalTime > currentTime {
+				if nextArrival < 0 {
+					nextArrival = p.ArrivalTime
+				}
+				continue
+			}
+			if next < 0 || throttled(processes[next]) && !throttled(p) {
+				next = i
+			}
+		}
+		if next < 0 {
+			// Nothing is ready, so skip ahead to the next arrival, resetting the budgets if a window starts on the way.
+			if nextArrival/window > currentTime/window {
+				clear(spent)
+			}
+			currentTime = nextArrival
+			continue
+		}
+
+		p := processes[next]
+		if firstRun[next] < 0 {
+			firstRun[next] = currentTime
+		}
+		if group := usage[p.Cgroup]; group != nil {
+			if throttled(p) {
+				group.OverBudget++
+			}
+			group.Used++
+			spent[p.Cgroup]++
+		}
+		// Consecutive ticks of the same process are one slice.
+		if last := len(gantt) - 1; last >= 0 && gantt[last].PID == p.ProcessID && gantt[last].Stop == currentTime {
+			gantt[last].Stop++
+		} else {
+			gantt = append(gantt, TimeSlice{PID: p.ProcessID, Start: currentTime, Stop: currentTime + 1})
+		}
+		currentTime++
+
+		remaining[next]--
+		if remaining[next] == 0 {
+			done++
+			turnaround := currentTime - p.ArrivalTime
+			schedule[next] = ScheduleRow{
+				ProcessID:     p.ProcessID,
+				Priority:      p.Priority,
+				BurstDuration: p.BurstDuration,
+				ArrivalTime:   p.ArrivalTime,
+				Wait:          turnaround - p.BurstDuration,
+				Turnaround:    turnaround,
+				Completion:    currentTime,
+				Response:      firstRun[next] - p.ArrivalTime,
+			}
+		}
+	}
+
+	result := newScheduleResult(gantt, schedule)
+	for _, group := range usage {
+		result.GroupUsage = append(result.GroupUsage, *group)
+	}
+	sort.Slice(result.GroupUsage, func(i, j int) bool { return result.GroupUsage[i].Cgroup < result.GroupUsage[j].Cgroup })
+
+	return result
+}
+
+// outputGroupUsage writes a table of the CPU time each group used against its budget.
+func outputGroupUsage(w io.Writer, usage []GroupUsage) {
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Group budgets")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Group", "Budget", "CPU time", "Over budget"})
+	for _, group := range usage {
+		budget := "-"
+		if group.Budget > 0 {
+			budget = fmt.Sprint(group.Budget)
+		}
+		table.Append([]string{group.Cgroup, budget, fmt.Sprint(group.Used), fmt.Sprint(group.OverBudget)})
+	}
+	table.Render()
+}
+
+//endregion
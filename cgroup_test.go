@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBudgetSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A1", ArrivalTime: 0, BurstDuration: 6, Cgroup: "a"},
+		{ProcessID: "B1", ArrivalTime: 1, BurstDuration: 4, Cgroup: "b"},
+	}
+	tests := []struct {
+		name      string
+		budgets   map[string]int64
+		wantGantt []TimeSlice
+		wantUsage []GroupUsage
+	}{
+		{
+			name:      "unlimited",
+			wantGantt: []TimeSlice{{PID: "A1", Start: 0, Stop: 6}, {PID: "B1", Start: 6, Stop: 10}},
+			wantUsage: []GroupUsage{{Cgroup: "a", Used: 6}, {Cgroup: "b", Used: 4}},
+		},
+		{
+			// a spends its 2 units of each window by time 2 and 7, and B1 takes over until the window resets.
+			// Once B1 is done, A1 runs over budget as nothing else is ready.
+			name:    "throttled",
+			budgets: map[string]int64{"a": 2, "b": 4},
+			wantGantt: []TimeSlice{
+				{PID: "A1", Start: 0, Stop: 2},
+				{PID: "B1", Start: 2, Stop: 5},
+				{PID: "A1", Start: 5, Stop: 7},
+				{PID: "B1", Start: 7, Stop: 8},
+				{PID: "A1", Start: 8, Stop: 10},
+			},
+			wantUsage: []GroupUsage{{Cgroup: "a", Budget: 2, Used: 6, OverBudget: 2}, {Cgroup: "b", Budget: 4, Used: 4}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			got := BudgetSchedule(&w, "Budgets", processes, tt.budgets, 5)
+			if diff := cmp.Diff(got.Gantt, tt.wantGantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(got.GroupUsage, tt.wantUsage); diff != "" {
+				t.Errorf(diff)
+			}
+			if !strings.Contains(w.String(), "Group budgets\n") {
+				t.Errorf("missing group budgets in:\n%s", w.String())
+			}
+		})
+	}
+
+	// The budgets also reset across an idle stretch that skips over the start of a window.
+	got := budgeted([]Process{
+		{ProcessID: "A1", BurstDuration: 1, Cgroup: "a"},
+		{ProcessID: "A2", ArrivalTime: 7, BurstDuration: 2, Cgroup: "a"},
+		{ProcessID: "C", ArrivalTime: 7, BurstDuration: 1},
+	}, map[string]int64{"a": 1}, 5)
+	want := []TimeSlice{{PID: "A1", Start: 0, Stop: 1}, {PID: "A2", Start: 7, Stop: 8}, {PID: "C", Start: 8, Stop: 9}, {PID: "A2", Start: 9, Stop: 10}}
+	if diff := cmp.Diff(got.Gantt, want); diff != "" {
+		t.Errorf(diff)
+	}
+
+	var w bytes.Buffer
+	BudgetSchedule(&w, "Budgets", processes, map[string]int64{"a": 0}, 5)
+	if diff := cmp.Diff(w.String(), "invalid budget 0 for group \"a\": must be greater than 0\n"); diff != "" {
+		t.Errorf(diff)
+	}
+	if got := BudgetSchedule(io.Discard, "Budgets", processes, nil, 0); got.Rows != nil {
+		t.Errorf("BudgetSchedule() with a zero window = %+v, want no rows", got)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sync"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Comparison
+
+// bestMark flags the best value in each column of the comparison table.
+const bestMark = " *"
+
+// CompareSchedulers runs processes through each of FCFS, SJF, SRTF, Priority, and RR (with quantum) and writes
+// a table of their average wait, average turnaround, and throughput, marking the best value in each column.
+// The algorithms run concurrently, each on its own copy of processes. The results are returned in the order of the table.
+func CompareSchedulers(w io.Writer, processes []Process, quantum int64) []ScheduleResult {
+	if !schedulable(w, processes) {
+		return nil
+	}
+
+	results := ScheduleConcurrently(processes, FCFS{}, SJF{}, SRTF{}, Priority{}, RR{Quantum: quantum})
+
+	bestWait, bestTurnaround, bestThroughput := results[0].AveWait, results[0].AveTurnaround, results[0].Throughput
+	for _, result := range results[1:] {
+		if result.AveWait < bestWait {
+			bestWait = result.AveWait
+		}
+		if result.AveTurnaround < bestTurnaround {
+			bestTurnaround = result.AveTurnaround
+		}
+		if result.Throughput > bestThroughput {
+			bestThroughput = result.Throughput
+		}
+	}
+	mark := func(value, best float64) string {
+		if value == best {
+			return fmt.Sprintf("%.2f", value) + bestMark
+		}
+		return fmt.Sprintf("%.2f", value)
+	}
+
+	outputTitle(w, "Comparison")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Average wait", "Average turnaround", "Throughput"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for _, result := range results {
+		table.Append([]string{
+			result.Title,
+			mark(result.AveWait, bestWait),
+			mark(result.AveTurnaround, bestTurnaround),
+			mark(result.Throughput, bestThroughput),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w, "* best in column")
+
+	return results
+}
+
+// ScheduleConcurrently returns the schedule of processes by each of schedulers, in the same order.
+// Each runs in its own goroutine on its own copy of processes, so schedulers share nothing they could race on.
+func ScheduleConcurrently(processes []Process, schedulers ...Scheduler) []ScheduleResult {
+	results := make([]ScheduleResult, len(schedulers))
+	var wg sync.WaitGroup
+	for i, scheduler := range schedulers {
+		wg.Add(1)
+		go func(i int, scheduler Scheduler) {
+			defer wg.Done()
+			results[i] = scheduler.Schedule(cloneProcesses(processes))
+		}(i, scheduler)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// RecomputeWithout returns the schedule scheduler computes for processes without the one whose ProcessID is id,
+// to see how the rest would fare had it never arrived. processes is not modified; if none has that ID,
+// the whole set is scheduled.
+func RecomputeWithout(processes []Process, id string, scheduler Scheduler) ScheduleResult {
+	rest := make([]Process, 0, len(processes))
+	for _, p := range processes {
+		if p.ProcessID != id {
+			rest = append(rest, p)
+		}
+	}
+
+	return scheduler.Schedule(rest)
+}
+
+// resultEpsilon is how far apart two averages may be for DiffScheduleResults to count them as equal.
+const resultEpsilon = 1e-9
+
+// Equal reports whether r and other are the same schedule, as DiffScheduleResults decides.
+func (r ScheduleResult) Equal(other ScheduleResult) bool {
+	return len(DiffScheduleResults(r, other)) == 0
+}
+
+// DiffScheduleResults lists how b differs from a, one line per difference, or nil if they are the same:
+// the title, each gantt slice by position, each row by ProcessID field by field, and each average and total.
+// Averages within resultEpsilon of each other are equal, and a difference between them is written to 4 significant figures
+// with how far b is from a. Each line gives a's value before b's.
+func DiffScheduleResults(a, b ScheduleResult) []string {
+	var diffs []string
+	differ := func(format string, args ...any) {
+		diffs = append(diffs, fmt.Sprintf(format, args...))
+	}
+
+	if a.Title != b.Title {
+		differ("title: %q != %q", a.Title, b.Title)
+	}
+
+	for i := 0; i < max(len(a.Gantt), len(b.Gantt)); i++ {
+		switch {
+		case i >= len(b.Gantt):
+			differ("gantt[%d]: %v only in a", i, a.Gantt[i])
+		case i >= len(a.Gantt):
+			differ("gantt[%d]: %v only in b", i, b.Gantt[i])
+		case a.Gantt[i] != b.Gantt[i]:
+			differ("gantt[%d]: %v != %v", i, a.Gantt[i], b.Gantt[i])
+		}
+	}
+
+	bRows := make(map[string]ScheduleRow, len(b.Rows))
+	for _, row := range b.Rows {
+		bRows[row.ProcessID] = row
+	}
+	seen := make(map[string]bool, len(a.Rows))
+	for _, row := range a.Rows {
+		seen[row.ProcessID] = true
+		other, ok := bRows[row.ProcessID]
+		if !ok {
+			differ("row %q: only in a", row.ProcessID)
+			continue
+		}
+		for _, field := range []struct {
+			name         string
+			value, other int64
+		}{
+			{"priority", row.Priority, other.Priority},
+			{"burst", row.BurstDuration, other.BurstDuration},
+			{"arrival", row.ArrivalTime, other.ArrivalTime},
+			{"wait", row.Wait, other.Wait},
+			{"turnaround", row.Turnaround, other.Turnaround},
+			{"completion", row.Completion, other.Completion},
+			{"response", row.Response, other.Response},
+			{"blocked", row.Blocked, other.Blocked},
+		} {
+			if field.value != field.other {
+				differ("row %q: %s %d != %d", row.ProcessID, field.name, field.value, field.other)
+			}
+		}
+	}
+	for _, row := range b.Rows {
+		if !seen[row.ProcessID] {
+			differ("row %q: only in b", row.ProcessID)
+		}
+	}
+
+	for _, average := range []struct {
+		name         string
+		value, other float64
+	}{
+		{"average wait", a.AveWait, b.AveWait},
+		{"average turnaround", a.AveTurnaround, b.AveTurnaround},
+		{"average response", a.AveResponse, b.AveResponse},
+		{"throughput", a.Throughput, b.Throughput},
+		{"average completion", a.AveCompletion, b.AveCompletion},
+	} {
+		if math.Abs(average.value-average.other) > resultEpsilon {
+			differ("%s: %.4g != %.4g (by %+.4g)", average.name, average.value, average.other, average.other-average.value)
+		}
+	}
+	if a.Makespan != b.Makespan {
+		differ("makespan: %d != %d", a.Makespan, b.Makespan)
+	}
+	if a.DeadlineMisses != b.DeadlineMisses {
+		differ("deadline misses: %d != %d", a.DeadlineMisses, b.DeadlineMisses)
+	}
+	if a.CapPromotions != b.CapPromotions {
+		differ("cap promotions: %d != %d", a.CapPromotions, b.CapPromotions)
+	}
+	if !reflect.DeepEqual(a.FairShares, b.FairShares) {
+		differ("fair shares: %v != %v", a.FairShares, b.FairShares)
+	}
+	if !reflect.DeepEqual(a.GangDelays, b.GangDelays) {
+		differ("gang delays: %v != %v", a.GangDelays, b.GangDelays)
+	}
+	if !reflect.DeepEqual(a.GroupUsage, b.GroupUsage) {
+		differ("group usage: %v != %v", a.GroupUsage, b.GroupUsage)
+	}
+
+	return diffs
+}
+
+//endregion
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompareSchedulers(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	// The long job arriving first makes FCFS and Priority wait the longest, while SRTF preempts it.
+	results := CompareSchedulers(&w, []Process{
+		{ProcessID: "L", ArrivalTime: 0, BurstDuration: 10, Priority: 1},
+		{ProcessID: "S1", ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+		{ProcessID: "S2", ArrivalTime: 2, BurstDuration: 2, Priority: 3},
+	}, 2)
+
+	var titles []string
+	for _, result := range results {
+		titles = append(titles, result.Title)
+	}
+	wantTitles := []string{"First-come, first-serve", "Shortest-job-first", "Shortest-remaining-time-first", "Priority", "Round-robin"}
+	if diff := cmp.Diff(titles, wantTitles); diff != "" {
+		t.Errorf(diff)
+	}
+
+	rows := make(map[string]string)
+	for _, line := range strings.Split(w.String(), "\n") {
+		for _, title := range wantTitles {
+			if strings.HasPrefix(line, "| "+title+" ") {
+				rows[title] = line
+			}
+		}
+	}
+	if len(rows) != len(wantTitles) {
+		t.Fatalf("got %d algorithm rows, want %d:\n%s", len(rows), len(wantTitles), w.String())
+	}
+	// SRTF has the least wait and turnaround (L waits 4, S1 0, S2 1), and everything finishes at 14 whatever the order.
+	for title, row := range rows {
+		cells := strings.Split(row, "|")
+		wantBest := title == "Shortest-remaining-time-first"
+		if got := strings.Contains(cells[2], bestMark); got != wantBest {
+			t.Errorf("%s wait marked best = %v, want %v: %s", title, got, wantBest, row)
+		}
+		if got := strings.Contains(cells[3], bestMark); got != wantBest {
+			t.Errorf("%s turnaround marked best = %v, want %v: %s", title, got, wantBest, row)
+		}
+		if !strings.Contains(cells[4], bestMark) {
+			t.Errorf("%s throughput not marked best: %s", title, row)
+		}
+	}
+	if !strings.Contains(rows["Shortest-remaining-time-first"], "1.67"+bestMark) {
+		t.Errorf("SRTF wait is not 1.67: %s", rows["Shortest-remaining-time-first"])
+	}
+}
+
+func TestScheduleConcurrently(t *testing.T) {
+	t.Parallel()
+	// Run under -race, this shows the schedulers share no mutable state with each other or the caller.
+	processes := GenerateProcesses(200, 1, GenOptions{})
+	processes[0].Bursts = []BurstSegment{{Kind: CPUBurst, Duration: processes[0].BurstDuration}}
+	original := cloneProcesses(processes)
+	schedulers := []Scheduler{FCFS{}, SJF{}, SRTF{}, Priority{Preemptive: true}, RR{Quantum: 3}}
+
+	got := ScheduleConcurrently(processes, schedulers...)
+	if len(got) != len(schedulers) {
+		t.Fatalf("got %d results for %d schedulers", len(got), len(schedulers))
+	}
+	for i, scheduler := range schedulers {
+		if diff := cmp.Diff(got[i], scheduler.Schedule(original)); diff != "" {
+			t.Errorf("%T: %s", scheduler, diff)
+		}
+	}
+	if diff := cmp.Diff(processes, original); diff != "" {
+		t.Errorf("processes was modified: %s", diff)
+	}
+
+	// CompareSchedulers runs concurrently too, and callers may run it concurrently on the same input.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			CompareSchedulers(io.Discard, processes, 3)
+		}()
+	}
+	wg.Wait()
+	if diff := cmp.Diff(processes, original); diff != "" {
+		t.Errorf("processes was modified: %s", diff)
+	}
+}
+
+func TestRecomputeWithout(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P1", BurstDuration: 10},
+		{ProcessID: "P2", ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: "P3", ArrivalTime: 2, BurstDuration: 3},
+	}
+	original := cloneProcesses(processes)
+	before := FCFS{}.Schedule(processes)
+	after := RecomputeWithout(processes, "P1", FCFS{})
+	if len(after.Rows) != 2 {
+		t.Fatalf("got %d rows, want 2: %+v", len(after.Rows), after.Rows)
+	}
+	for _, row := range after.Rows {
+		if row.ProcessID == "P1" {
+			t.Errorf("P1 is still scheduled: %+v", after.Rows)
+		}
+	}
+	if after.AveWait >= before.AveWait {
+		t.Errorf("average wait without the longest job is %v, want less than %v", after.AveWait, before.AveWait)
+	}
+	if diff := cmp.Diff(processes, original); diff != "" {
+		t.Errorf("processes was modified: %s", diff)
+	}
+
+	// An unknown ID schedules everything.
+	if diff := DiffScheduleResults(before, RecomputeWithout(processes, "P9", FCFS{})); diff != nil {
+		t.Errorf("unknown ID changed the schedule: %v", diff)
+	}
+}
+
+func TestDiffScheduleResults(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", BurstDuration: 4},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 3},
+	}
+	a := FCFSSchedule(io.Discard, "FCFS", processes)
+	b := FCFSSchedule(io.Discard, "FCFS", processes)
+	if !a.Equal(b) {
+		t.Errorf("identical results differ: %v", DiffScheduleResults(a, b))
+	}
+	// Averages computed a different way are still equal.
+	b.AveWait += resultEpsilon / 2
+	if !a.Equal(b) {
+		t.Errorf("results within epsilon differ: %v", DiffScheduleResults(a, b))
+	}
+
+	// One slice ends a unit later.
+	b = FCFSSchedule(io.Discard, "FCFS", processes)
+	b.Gantt = append([]TimeSlice(nil), b.Gantt...)
+	b.Gantt[1].Stop++
+	want := []string{"gantt[1]: P1 [4,7) != P1 [4,8)"}
+	if diff := cmp.Diff(DiffScheduleResults(a, b), want); diff != "" {
+		t.Errorf(diff)
+	}
+	if a.Equal(b) {
+		t.Errorf("Equal() = true for results with different gantts")
+	}
+
+	c := FCFSSchedule(io.Discard, "FCFS", processes[:1])
+	want = []string{
+		"gantt[1]: P1 [4,7) only in a",
+		`row "P1": only in a`,
+		"average wait: 1.5 != 0 (by -1.5)",
+		"average turnaround: 5 != 4 (by -1)",
+		"average response: 1.5 != 0 (by -1.5)",
+		"throughput: 0.2857 != 0.25 (by -0.03571)",
+		"average completion: 5.5 != 4 (by -1.5)",
+		"makespan: 7 != 4",
+	}
+	if diff := cmp.Diff(DiffScheduleResults(a, c), want); diff != "" {
+		t.Errorf(diff)
+	}
+}
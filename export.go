@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//region Machine-readable output
+
+// WriteScheduleJSON writes result to w as indented JSON.
+// Fields are always written in the same order, so the output is stable for a given result.
+func WriteScheduleJSON(w io.Writer, result ScheduleResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		return fmt.Errorf("%w: writing schedule JSON", err)
+	}
+
+	return nil
+}
+
+// WriteScheduleCSV writes the rows of result to w as CSV for a spreadsheet: a header row,
+// then a row per process with its times as plain integers.
+func WriteScheduleCSV(w io.Writer, result ScheduleResult) error {
+	records := [][]string{{"ProcessID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Completion", "Response"}}
+	for _, row := range result.Rows {
+		records = append(records, []string{
+			row.ProcessID,
+			strconv.FormatInt(row.Priority, 10),
+			strconv.FormatInt(row.BurstDuration, 10),
+			strconv.FormatInt(row.ArrivalTime, 10),
+			strconv.FormatInt(row.Wait, 10),
+			strconv.FormatInt(row.Turnaround, 10),
+			strconv.FormatInt(row.Completion, 10),
+			strconv.FormatInt(row.Response, 10),
+		})
+	}
+	if err := csv.NewWriter(w).WriteAll(records); err != nil {
+		return fmt.Errorf("%w: writing schedule CSV", err)
+	}
+
+	return nil
+}
+
+// WriteGanttCSV writes gantt to w as CSV with a PID,Start,Stop header and a row per slice.
+// Context switches are labelled as in the gantt chart, and a chart across several CPUs gets a CPU column as well.
+func WriteGanttCSV(w io.Writer, gantt []TimeSlice) error {
+	multi := cpuCount(gantt) > 1
+	header := []string{"PID", "Start", "Stop"}
+	if multi {
+		header = append(header, "CPU")
+	}
+	records := [][]string{header}
+	for _, slice := range gantt {
+		record := []string{blockLabel(slice), strconv.FormatInt(slice.Start, 10), strconv.FormatInt(slice.Stop, 10)}
+		if multi {
+			record = append(record, strconv.Itoa(slice.CPU))
+		}
+		records = append(records, record)
+	}
+	if err := csv.NewWriter(w).WriteAll(records); err != nil {
+		return fmt.Errorf("%w: writing gantt CSV", err)
+	}
+
+	return nil
+}
+
+// WriteScheduleMarkdown writes result to w as GitHub-flavored Markdown:
+// the schedule table, the gantt slices as a PID/Start/Stop table, and the averages as a bulleted list.
+// Numeric columns are right-aligned.
+func WriteScheduleMarkdown(w io.Writer, result ScheduleResult) error {
+	var b strings.Builder
+	if result.Title != "" {
+		_, _ = fmt.Fprintf(&b, "### %s\n\n", result.Title)
+	}
+
+	b.WriteString("| ID | Priority | Burst | Arrival | Wait | Turnaround | Exit |\n")
+	b.WriteString("|:---|---:|---:|---:|---:|---:|---:|\n")
+	for _, row := range result.Rows {
+		_, _ = fmt.Fprintf(&b, "| %s | %d | %d | %d | %d | %d | %d |\n",
+			row.ProcessID, row.Priority, row.BurstDuration, row.ArrivalTime, row.Wait, row.Turnaround, row.Completion)
+	}
+
+	b.WriteString("\n| PID | Start | Stop |\n")
+	b.WriteString("|:---|---:|---:|\n")
+	for _, slice := range result.Gantt {
+		_, _ = fmt.Fprintf(&b, "| %s | %d | %d |\n", blockLabel(slice), slice.Start, slice.Stop)
+	}
+
+	_, _ = fmt.Fprintf(&b, "\n- Average wait: %.2f\n", result.AveWait)
+	_, _ = fmt.Fprintf(&b, "- Average turnaround: %.2f\n", result.AveTurnaround)
+	_, _ = fmt.Fprintf(&b, "- Average response: %.2f\n", result.AveResponse)
+	_, _ = fmt.Fprintf(&b, "- Throughput: %.2f\n", result.Throughput)
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("%w: writing schedule Markdown", err)
+	}
+
+	return nil
+}
+
+// WriteGanttMermaid writes gantt to w as a Mermaid gantt diagram, with each slice a task from its start lasting its duration.
+// Times are written as seconds since the epoch, which the X date format reads as plain numbers.
+// Idle time has no task, so it shows as a gap. A chart across several CPUs has a section per CPU.
+func WriteGanttMermaid(w io.Writer, gantt []TimeSlice, title string) error {
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	if title != "" {
+		_, _ = fmt.Fprintf(&b, "    title %s\n", title)
+	}
+	b.WriteString("    dateFormat X\n")
+	b.WriteString("    axisFormat %s\n")
+
+	cpus := cpuCount(gantt)
+	for cpu := 0; cpu < cpus; cpu++ {
+		_, _ = fmt.Fprintf(&b, "    section CPU %d\n", cpu)
+		for _, slice := range gantt {
+			if slice.CPU != cpu {
+				continue
+			}
+			// A colon would end the task name early.
+			label := strings.ReplaceAll(blockLabel(slice), ":", "_")
+			_, _ = fmt.Fprintf(&b, "    %s : %d, %ds\n", label, slice.Start, slice.Stop-slice.Start)
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("%w: writing gantt Mermaid", err)
+	}
+
+	return nil
+}
+
+// WriteTimeline writes gantt to w as a line per instant something starts or stops, in time order:
+//
+//	t=0: P0 starts
+//	t=4: P0 ends / P1 starts
+//	t=7: P1 ends / idle starts
+//
+// Each line lists what ends before what starts. Idle time is an event like any process,
+// and on a chart across several CPUs every event names its CPU.
+func WriteTimeline(w io.Writer, gantt []TimeSlice) error {
+	type event struct {
+		time  int64
+		start bool
+		cpu   int
+		text  string
+	}
+
+	cpus := cpuCount(gantt)
+	rows := make([][]TimeSlice, cpus)
+	for _, slice := range gantt {
+		rows[slice.CPU] = append(rows[slice.CPU], slice)
+	}
+	var events []event
+	for cpu, row := range rows {
+		where := ""
+		if cpus > 1 {
+			where = fmt.Sprintf(" on CPU %d", cpu)
+		}
+		for _, block := range ganttBlocks(row) {
+			label := blockLabel(block)
+			events = append(events,
+				event{time: block.Start, start: true, cpu: cpu, text: label + " starts" + where},
+				event{time: block.Stop, cpu: cpu, text: label + " ends" + where})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.time != b.time {
+			return a.time < b.time
+		}
+		if a.start != b.start {
+			return !a.start
+		}
+		return a.cpu < b.cpu
+	})
+
+	var b strings.Builder
+	for i := 0; i < len(events); {
+		texts := []string{events[i].text}
+		j := i + 1
+		for ; j < len(events) && events[j].time == events[i].time; j++ {
+			texts = append(texts, events[j].text)
+		}
+		_, _ = fmt.Fprintf(&b, "t=%d: %s\n", events[i].time, strings.Join(texts, " / "))
+		i = j
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("%w: writing timeline", err)
+	}
+
+	return nil
+}
+
+//endregion
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteScheduleJSON(t *testing.T) {
+	t.Parallel()
+	result := ScheduleResult{
+		Title: "First-come, first-serve",
+		Gantt: []TimeSlice{
+			{PID: "P0", Start: 0, Stop: 5},
+			{PID: "P1", Start: 5, Stop: 14},
+		},
+		Rows: []ScheduleRow{
+			{ProcessID: "P0", Priority: 2, BurstDuration: 5, ArrivalTime: 0, Wait: 0, Turnaround: 5, Completion: 5},
+			{ProcessID: "P1", Priority: 1, BurstDuration: 9, ArrivalTime: 3, Wait: 2, Turnaround: 11, Completion: 14},
+		},
+		AveWait:       1,
+		AveTurnaround: 8,
+		Throughput:    2.0 / 14,
+	}
+
+	var w bytes.Buffer
+	if err := WriteScheduleJSON(&w, result); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Title string `json:"title"`
+		Rows  []struct {
+			ProcessID string `json:"processId"`
+			Wait      int64  `json:"wait"`
+		} `json:"rows"`
+		Gantt       []TimeSlice `json:"gantt"`
+		AverageWait float64     `json:"averageWait"`
+	}
+	if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, w.String())
+	}
+	if got.Title != result.Title {
+		t.Errorf("title = %q, want %q", got.Title, result.Title)
+	}
+	if len(got.Rows) != 2 || got.Rows[1].ProcessID != "P1" || got.Rows[1].Wait != 2 {
+		t.Errorf("unexpected rows %+v", got.Rows)
+	}
+	if diff := cmp.Diff(got.Gantt, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if got.AverageWait != 1 {
+		t.Errorf("averageWait = %v, want 1", got.AverageWait)
+	}
+
+	// Writing the same result again produces identical bytes.
+	var again bytes.Buffer
+	_ = WriteScheduleJSON(&again, result)
+	if again.String() != w.String() {
+		t.Errorf("output is not deterministic")
+	}
+}
+
+func TestWriteScheduleCSV(t *testing.T) {
+	t.Parallel()
+	result := ScheduleResult{
+		Gantt: []TimeSlice{
+			{PID: "P0", Start: 0, Stop: 5},
+			{Start: 5, Stop: 6, Switch: true},
+			{PID: "P1, the second", Start: 6, Stop: 15},
+		},
+		Rows: []ScheduleRow{
+			{ProcessID: "P0", Priority: 2, BurstDuration: 5, Turnaround: 5, Completion: 5},
+			{ProcessID: "P1, the second", Priority: 1, BurstDuration: 9, ArrivalTime: 3, Wait: 3, Turnaround: 12, Completion: 15, Response: 3},
+		},
+	}
+
+	var w bytes.Buffer
+	if err := WriteScheduleCSV(&w, result); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&w).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRows := [][]string{
+		{"ProcessID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Completion", "Response"},
+		{"P0", "2", "5", "0", "0", "5", "5", "0"},
+		{"P1, the second", "1", "9", "3", "3", "12", "15", "3"},
+	}
+	if diff := cmp.Diff(rows, wantRows); diff != "" {
+		t.Errorf(diff)
+	}
+
+	w.Reset()
+	if err := WriteGanttCSV(&w, result.Gantt); err != nil {
+		t.Fatal(err)
+	}
+	slices, err := csv.NewReader(&w).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSlices := [][]string{
+		{"PID", "Start", "Stop"},
+		{"P0", "0", "5"},
+		{switchLabel, "5", "6"},
+		{"P1, the second", "6", "15"},
+	}
+	if diff := cmp.Diff(slices, wantSlices); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestWriteScheduleMarkdown(t *testing.T) {
+	t.Parallel()
+	result := ScheduleResult{
+		Title: "Shortest-job-first",
+		Gantt: []TimeSlice{
+			{PID: "P0", Start: 0, Stop: 5},
+			{PID: "P1", Start: 7, Stop: 8},
+		},
+		Rows: []ScheduleRow{
+			{ProcessID: "P0", Priority: 2, BurstDuration: 5, Turnaround: 5, Completion: 5},
+			{ProcessID: "P1", Priority: 1, BurstDuration: 1, ArrivalTime: 7, Turnaround: 1, Completion: 8},
+		},
+		AveTurnaround: 3,
+		Throughput:    0.25,
+	}
+
+	var w bytes.Buffer
+	if err := WriteScheduleMarkdown(&w, result); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(w.String(), "\n")
+	want := []string{
+		"### Shortest-job-first",
+		"",
+		"| ID | Priority | Burst | Arrival | Wait | Turnaround | Exit |",
+		"|:---|---:|---:|---:|---:|---:|---:|",
+		"| P0 | 2 | 5 | 0 | 0 | 5 | 5 |",
+		"| P1 | 1 | 1 | 7 | 0 | 1 | 8 |",
+		"",
+		"| PID | Start | Stop |",
+		"|:---|---:|---:|",
+		"| P0 | 0 | 5 |",
+		"| P1 | 7 | 8 |",
+		"",
+		"- Average wait: 0.00",
+		"- Average turnaround: 3.00",
+		"- Average response: 0.00",
+		"- Throughput: 0.25",
+		"",
+	}
+	if diff := cmp.Diff(lines, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestWriteGanttMermaid(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: "P0", Start: 0, Stop: 5},
+		{PID: "P1", Start: 7, Stop: 8},
+		{Start: 8, Stop: 9, Switch: true},
+		{PID: "P0", Start: 9, Stop: 12},
+	}
+
+	var w bytes.Buffer
+	if err := WriteGanttMermaid(&w, gantt, "Round-robin"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(w.String(), "gantt\n") {
+		t.Errorf("output does not begin with gantt:\n%s", w.String())
+	}
+	want := []string{
+		"gantt",
+		"    title Round-robin",
+		"    dateFormat X",
+		"    axisFormat %s",
+		"    section CPU 0",
+		"    P0 : 0, 5s",
+		"    P1 : 7, 1s",
+		"    cs : 8, 1s",
+		"    P0 : 9, 3s",
+		"",
+	}
+	if diff := cmp.Diff(strings.Split(w.String(), "\n"), want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestWriteTimeline(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		gantt   []TimeSlice
+		wantOut string
+	}{
+		{
+			name: "fcfs",
+			gantt: firstComeFirstServe([]Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 3},
+				{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 2},
+			}, options{}).Gantt,
+			wantOut: "t=0: P0 starts\n" +
+				"t=4: P0 ends / P1 starts\n" +
+				"t=7: P1 ends / P2 starts\n" +
+				"t=9: P2 ends\n",
+		},
+		{
+			name: "idle",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 2, Stop: 4},
+				{PID: "P1", Start: 6, Stop: 7},
+			},
+			wantOut: "t=0: idle starts\n" +
+				"t=2: idle ends / P0 starts\n" +
+				"t=4: P0 ends / idle starts\n" +
+				"t=6: idle ends / P1 starts\n" +
+				"t=7: P1 ends\n",
+		},
+		{
+			name: "two CPUs",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 3},
+				{PID: "P1", Start: 0, Stop: 2, CPU: 1},
+			},
+			wantOut: "t=0: P0 starts on CPU 0 / P1 starts on CPU 1\n" +
+				"t=2: P1 ends on CPU 1\n" +
+				"t=3: P0 ends on CPU 0\n",
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := WriteTimeline(&w, tt.gantt); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(w.String(), tt.wantOut); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
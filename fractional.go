@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Fractional time
+
+// timeEpsilon is how close two fractional times must be to count as equal, so that rounding error
+// in sums like 0.1+0.2 does not decide which job runs first.
+const timeEpsilon = 1e-9
+
+type (
+	// FractionalProcess is a Process whose arrival and burst may be non-integer.
+	FractionalProcess struct {
+		ProcessID     string
+		ArrivalTime   float64
+		BurstDuration float64
+		Priority      int64
+	}
+	FractionalSlice struct {
+		PID   string  `json:"pid"`
+		Start float64 `json:"start"`
+		Stop  float64 `json:"stop"`
+	}
+	// FractionalRow is the computed timing of one fractional process in a schedule.
+	FractionalRow struct {
+		ProcessID     string  `json:"processId"`
+		Priority      int64   `json:"priority"`
+		BurstDuration float64 `json:"burstDuration"`
+		ArrivalTime   float64 `json:"arrivalTime"`
+		Wait          float64 `json:"wait"`
+		Turnaround    float64 `json:"turnaround"`
+		Completion    float64 `json:"completion"`
+		Response      float64 `json:"response"`
+	}
+	// FractionalResult is a ScheduleResult in fractional time.
+	FractionalResult struct {
+		Title         string            `json:"title"`
+		Gantt         []FractionalSlice `json:"gantt"`
+		Rows          []FractionalRow   `json:"rows"`
+		AveWait       float64           `json:"averageWait"`
+		AveTurnaround float64           `json:"averageTurnaround"`
+		AveResponse   float64           `json:"averageResponse"`
+		Throughput    float64           `json:"throughput"`
+	}
+)
+
+// FCFSScheduleFractional outputs and returns a first-come, first-serve schedule of fractional processes,
+// run in arrival order with ties in input order.
+func FCFSScheduleFractional(w io.Writer, title string, processes []FractionalProcess) FractionalResult {
+	if !schedulableFractional(w, processes) {
+		return FractionalResult{Title: title}
+	}
+	processes = slices.Clone(processes)
+
+	result := firstComeFirstServeFractional(processes)
+	result.Title = title
+	outputFractionalResult(w, result)
+
+	return result
+}
+
+func firstComeFirstServeFractional(processes []FractionalProcess) FractionalResult {
+	order := make([]int, len(processes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return processes[order[i]].ArrivalTime < processes[order[j]].ArrivalTime
+	})
+
+	var serviceTime float64
+	schedule := make([]FractionalRow, len(processes))
+	gantt := make([]FractionalSlice, 0, len(processes))
+	for _, i := range order {
+		serviceTime = math.Max(serviceTime, processes[i].ArrivalTime)
+		schedule[i], gantt = runFractional(processes[i], serviceTime, gantt)
+		serviceTime = schedule[i].Completion
+	}
+
+	return newFractionalResult(gantt, schedule)
+}
+
+// SJFScheduleFractional outputs and returns a non-preemptive shortest-job-first schedule of fractional processes.
+// Bursts within timeEpsilon of each other are a tie, which goes to the smaller ProcessID as in SJFSchedule.
+func SJFScheduleFractional(w io.Writer, title string, processes []FractionalProcess) FractionalResult {
+	if !schedulableFractional(w, processes) {
+		return FractionalResult{Title: title}
+	}
+	processes = slices.Clone(processes)
+
+	result := shortestJobFirstFractional(processes)
+	result.Title = title
+	outputFractionalResult(w, result)
+
+	return result
+}
+
+func shortestJobFirstFractional(processes []FractionalProcess) FractionalResult {
+	var serviceTime float64
+	schedule := make([]FractionalRow, len(processes))
+	gantt := make([]FractionalSlice, 0, len(processes))
+	ran := make([]bool, len(processes))
+	for done := 0; done < len(processes); {
+		next := -1
+		nextArrival := math.Inf(1)
+		for i, p := range processes {
+			if ran[i] {
+				continue
+			}
+			if p.ArrivalTime > serviceTime+timeEpsilon {
+				nextArrival = math.Min(nextArrival, p.ArrivalTime)
+				continue
+			}
+			if next < 0 || shorterFractional(p, processes[next]) {
+				next = i
+			}
+		}
+		if next < 0 {
+			// No available jobs, jump to the next arrival.
+			serviceTime = nextArrival
+			continue
+		}
+
+		ran[next] = true
+		done++
+		schedule[next], gantt = runFractional(processes[next], serviceTime, gantt)
+		serviceTime = schedule[next].Completion
+	}
+
+	return newFractionalResult(gantt, schedule)
+}
+
+// shorterFractional reports whether a should run before b, treating bursts within timeEpsilon as equal.
+func shorterFractional(a, b FractionalProcess) bool {
+	if math.Abs(a.BurstDuration-b.BurstDuration) > timeEpsilon {
+		return a.BurstDuration < b.BurstDuration
+	}
+	return a.ProcessID < b.ProcessID
+}
+
+// runFractional runs p to completion from start, returning its row and the gantt with its slice added.
+func runFractional(p FractionalProcess, start float64, gantt []FractionalSlice) (FractionalRow, []FractionalSlice) {
+	completion := start + p.BurstDuration
+	gantt = append(gantt, FractionalSlice{PID: p.ProcessID, Start: start, Stop: completion})
+
+	return FractionalRow{
+		ProcessID:     p.ProcessID,
+		Priority:      p.Priority,
+		BurstDuration: p.BurstDuration,
+		ArrivalTime:   p.ArrivalTime,
+		Wait:          start - p.ArrivalTime,
+		Turnaround:    completion - p.ArrivalTime,
+		Completion:    completion,
+		Response:      start - p.ArrivalTime,
+	}, gantt
+}
+
+func newFractionalResult(gantt []FractionalSlice, rows []FractionalRow) FractionalResult {
+	result := FractionalResult{Gantt: gantt, Rows: rows}
+	if len(rows) == 0 {
+		return result
+	}
+	var lastCompletion float64
+	for _, row := range rows {
+		result.AveWait += row.Wait
+		result.AveTurnaround += row.Turnaround
+		result.AveResponse += row.Response
+		lastCompletion = math.Max(lastCompletion, row.Completion)
+	}
+	count := float64(len(rows))
+	result.AveWait /= count
+	result.AveTurnaround /= count
+	result.AveResponse /= count
+	if lastCompletion > 0 {
+		result.Throughput = count / lastCompletion
+	}
+
+	return result
+}
+
+// schedulableFractional is schedulable for fractional processes.
+func schedulableFractional(w io.Writer, processes []FractionalProcess) bool {
+	if len(processes) == 0 {
+		_, _ = fmt.Fprintln(w, "no processes to schedule")
+		return false
+	}
+	seen := make(map[string]bool, len(processes))
+	for _, p := range processes {
+		var err error
+		switch {
+		case math.IsNaN(p.BurstDuration) || math.IsInf(p.BurstDuration, 0):
+			err = fmt.Errorf("%w: %q has a burst duration %v that is not finite", ErrInvalidProcess, p.ProcessID, p.BurstDuration)
+		case math.IsNaN(p.ArrivalTime) || math.IsInf(p.ArrivalTime, 0):
+			err = fmt.Errorf("%w: %q has an arrival time %v that is not finite", ErrInvalidProcess, p.ProcessID, p.ArrivalTime)
+		case p.BurstDuration <= 0:
+			err = fmt.Errorf("%w: %q has a burst duration %v that is not positive", ErrInvalidProcess, p.ProcessID, p.BurstDuration)
+		case p.ArrivalTime < 0:
+			err = fmt.Errorf("%w: %q has a negative arrival time %v", ErrInvalidProcess, p.ProcessID, p.ArrivalTime)
+		case seen[p.ProcessID]:
+			err = fmt.Errorf("%w: %q is used by more than one process", ErrInvalidProcess, p.ProcessID)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintln(w, err)
+			return false
+		}
+		seen[p.ProcessID] = true
+	}
+
+	return true
+}
+
+// outputFractionalResult writes the title, the slices in run order, and the schedule table of a fractional schedule.
+func outputFractionalResult(w io.Writer, result FractionalResult) {
+	outputTitle(w, result.Title)
+
+	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	for _, slice := range result.Gantt {
+		_, _ = fmt.Fprintf(w, "%s: %s - %s\n", slice.PID, formatTime(slice.Start), formatTime(slice.Stop))
+	}
+	_, _ = fmt.Fprintln(w)
+
+	_, _ = fmt.Fprintln(w, "Schedule table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
+	for _, row := range result.Rows {
+		table.Append([]string{
+			row.ProcessID,
+			fmt.Sprint(row.Priority),
+			formatTime(row.BurstDuration),
+			formatTime(row.ArrivalTime),
+			formatTime(row.Wait),
+			formatTime(row.Turnaround),
+			formatTime(row.Completion),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "Average wait: %.2f\n", result.AveWait)
+	_, _ = fmt.Fprintf(w, "Average turnaround: %.2f\n", result.AveTurnaround)
+	_, _ = fmt.Fprintf(w, "Average response: %.2f\n", result.AveResponse)
+	_, _ = fmt.Fprintf(w, "Throughput: %.2f\n", result.Throughput)
+}
+
+// formatTime writes a fractional time with as few digits as it needs.
+func formatTime(t float64) string {
+	return strconv.FormatFloat(t, 'f', -1, 64)
+}
+
+//endregion
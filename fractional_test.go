@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_shortestJobFirstFractional(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []FractionalProcess
+		wantGantt []FractionalSlice
+		wantWait  float64
+	}{
+		{
+			name: "fractional bursts",
+			processes: []FractionalProcess{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 2.5},
+				{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 2},
+				{ProcessID: "P2", ArrivalTime: 0.5, BurstDuration: 1.25},
+			},
+			wantGantt: []FractionalSlice{
+				{PID: "P0", Start: 0, Stop: 2.5},
+				{PID: "P2", Start: 2.5, Stop: 3.75},
+				{PID: "P1", Start: 3.75, Stop: 5.75},
+			},
+			// P0 0, P1 2.75, P2 2
+			wantWait: 4.75 / 3,
+		},
+		{
+			name: "rounding error is a tie",
+			processes: []FractionalProcess{
+				{ProcessID: "P1", BurstDuration: 0.1 + 0.2},
+				{ProcessID: "P0", BurstDuration: 0.3},
+			},
+			wantGantt: []FractionalSlice{
+				{PID: "P0", Start: 0, Stop: 0.3},
+				{PID: "P1", Start: 0.3, Stop: 0.6},
+			},
+			wantWait: 0.15,
+		},
+		{
+			name: "idle until a fractional arrival",
+			processes: []FractionalProcess{
+				{ProcessID: "P0", ArrivalTime: 1.5, BurstDuration: 0.5},
+			},
+			wantGantt: []FractionalSlice{
+				{PID: "P0", Start: 1.5, Stop: 2},
+			},
+		},
+	}
+	approx := cmpopts.EquateApprox(0, timeEpsilon)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := shortestJobFirstFractional(tt.processes)
+			if diff := cmp.Diff(got.Gantt, tt.wantGantt, approx); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(got.AveWait, tt.wantWait, approx); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestFCFSScheduleFractional(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	got := FCFSScheduleFractional(&w, "First-come, first-serve", []FractionalProcess{
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 1.25},
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 2.5},
+	})
+	want := []FractionalRow{
+		{ProcessID: "P1", BurstDuration: 1.25, ArrivalTime: 1, Wait: 1.5, Turnaround: 2.75, Completion: 3.75, Response: 1.5},
+		{ProcessID: "P0", BurstDuration: 2.5, Turnaround: 2.5, Completion: 2.5},
+	}
+	if diff := cmp.Diff(got.Rows, want); diff != "" {
+		t.Errorf(diff)
+	}
+	if got.AveTurnaround != 2.625 {
+		t.Errorf("AveTurnaround = %v, want 2.625", got.AveTurnaround)
+	}
+	for _, line := range []string{"P0: 0 - 2.5\n", "P1: 2.5 - 3.75\n", "Average wait: 0.75\n"} {
+		if !strings.Contains(w.String(), line) {
+			t.Errorf("missing %q in:\n%s", line, w.String())
+		}
+	}
+
+	w.Reset()
+	FCFSScheduleFractional(&w, "First-come, first-serve", []FractionalProcess{{ProcessID: "P0", BurstDuration: 0}})
+	if diff := cmp.Diff(w.String(), "invalid process: \"P0\" has a burst duration 0 that is not positive\n"); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func Test_schedulableFractional(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		process FractionalProcess
+		wantOut string
+	}{
+		{
+			name:    "valid",
+			process: FractionalProcess{ProcessID: "P0", ArrivalTime: 0.5, BurstDuration: 1.5},
+		},
+		{
+			name:    "NaN burst",
+			process: FractionalProcess{ProcessID: "P0", BurstDuration: math.NaN()},
+			wantOut: "invalid process: \"P0\" has a burst duration NaN that is not finite\n",
+		},
+		{
+			name:    "infinite burst",
+			process: FractionalProcess{ProcessID: "P0", BurstDuration: math.Inf(1)},
+			wantOut: "invalid process: \"P0\" has a burst duration +Inf that is not finite\n",
+		},
+		{
+			name:    "negative infinite burst",
+			process: FractionalProcess{ProcessID: "P0", BurstDuration: math.Inf(-1)},
+			wantOut: "invalid process: \"P0\" has a burst duration -Inf that is not finite\n",
+		},
+		{
+			name:    "NaN arrival",
+			process: FractionalProcess{ProcessID: "P0", ArrivalTime: math.NaN(), BurstDuration: 1},
+			wantOut: "invalid process: \"P0\" has an arrival time NaN that is not finite\n",
+		},
+		{
+			name:    "infinite arrival",
+			process: FractionalProcess{ProcessID: "P0", ArrivalTime: math.Inf(1), BurstDuration: 1},
+			wantOut: "invalid process: \"P0\" has an arrival time +Inf that is not finite\n",
+		},
+		{
+			name:    "negative infinite arrival",
+			process: FractionalProcess{ProcessID: "P0", ArrivalTime: math.Inf(-1), BurstDuration: 1},
+			wantOut: "invalid process: \"P0\" has an arrival time -Inf that is not finite\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if got, want := schedulableFractional(&w, []FractionalProcess{tt.process}), tt.wantOut == ""; got != want {
+				t.Errorf("schedulableFractional() = %v, want %v", got, want)
+			}
+			if diff := cmp.Diff(w.String(), tt.wantOut); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
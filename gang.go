@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+//region Gang scheduling
+
+// GangDelay records a group that was ready to run but had to wait for enough CPUs to be free at once.
+type GangDelay struct {
+	GroupID string `json:"groupId"`
+	// Ready is when the group could first have started: its last member had arrived and every earlier group had started.
+	Ready int64 `json:"ready"`
+	Start int64 `json:"start"`
+	// CPUs is how many CPUs the group needed.
+	CPUs int `json:"cpus"`
+}
+
+// gang is the processes of one group, as indexes into the processes being scheduled, in input order.
+type gang struct {
+	id      string
+	members []int
+	arrival int64
+}
+
+// GangSchedule outputs and returns a first-come, first-serve schedule across cpus CPUs in which the processes
+// sharing a GroupID run as a gang: they all start at the same time, each on its own CPU, once the last of them arrives
+// and as many CPUs are free. A process without a GroupID is a gang of one. Gangs start in arrival order,
+// ties in input order, and a gang never starts before one ahead of it, even if it would fit sooner.
+// Each gang that had to wait for CPUs is reported, and the gantt chart has a row per CPU.
+func GangSchedule(w io.Writer, title string, processes []Process, cpus int) ScheduleResult {
+	if cpus < 1 {
+		_, _ = fmt.Fprintf(w, "invalid CPU count %d: must be at least 1\n", cpus)
+		return ScheduleResult{Title: title}
+	}
+	if !schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	for _, g := range gangs(processes) {
+		if len(g.members) > cpus {
+			_, _ = fmt.Fprintf(w, "%v: group %q has %d processes but there are only %d CPUs\n", ErrInvalidProcess, g.id, len(g.members), cpus)
+			return ScheduleResult{Title: title}
+		}
+	}
+
+	result := gangFirstComeFirstServe(processes, cpus)
+	result.Title = title
+	outputResult(w, result)
+	for _, delay := range result.GangDelays {
+		_, _ = fmt.Fprintf(w, "Group %s waited from %d to %d for %d CPUs\n", delay.GroupID, delay.Ready, delay.Start, delay.CPUs)
+	}
+
+	return result
+}
+
+// gangs groups processes by GroupID, in arrival order of their last member, ties in input order of their first.
+func gangs(processes []Process) []gang {
+	var groups []gang
+	byID := make(map[string]int)
+	for i, p := range processes {
+		g, ok := byID[p.GroupID]
+		if p.GroupID == "" || !ok {
+			g = len(groups)
+			groups = append(groups, gang{id: p.GroupID})
+			if p.GroupID != "" {
+				byID[p.GroupID] = g
+			}
+		}
+		groups[g].members = append(groups[g].members, i)
+		if p.ArrivalTime > groups[g].arrival {
+			groups[g].arrival = p.ArrivalTime
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].arrival < groups[j].arrival })
+
+	return groups
+}
+
+func gangFirstComeFirstServe(processes []Process, cpus int) ScheduleResult {
+	schedule := make([]ScheduleRow, len(processes))
+	gantt := make([]TimeSlice, 0)
+	free := make([]int64, cpus)
+	var delays []GangDelay
+
+	var lastStart int64
+	for _, g := range gangs(processes) {
+		// Take the CPUs that become free soonest, the lowest numbered on ties.
+		byFree := make([]int, cpus)
+		for c := range byFree {
+			byFree[c] = c
+		}
+		sort.SliceStable(byFree, func(i, j int) bool { return free[byFree[i]] < free[byFree[j]] })
+		chosen := byFree[:len(g.members)]
+		sort.Ints(chosen)
+
+		ready := g.arrival
+		if lastStart > ready {
+			ready = lastStart
+		}
+		start := ready
+		for _, c := range chosen {
+			if free[c] > start {
+				start = free[c]
+			}
+		}
+		if start > ready && g.id != "" {
+			delays = append(delays, GangDelay{GroupID: g.id, Ready: ready, Start: start, CPUs: len(g.members)})
+		}
+		lastStart = start
+
+		for k, i := range g.members {
+			cpu := chosen[k]
+			completion := start + processes[i].BurstDuration
+			free[cpu] = completion
+
+			schedule[i] = ScheduleRow{
+				ProcessID:     processes[i].ProcessID,
+				Priority:      processes[i].Priority,
+				BurstDuration: processes[i].BurstDuration,
+				ArrivalTime:   processes[i].ArrivalTime,
+				Wait:          start - processes[i].ArrivalTime,
+				Turnaround:    completion - processes[i].ArrivalTime,
+				Completion:    completion,
+				Response:      start - processes[i].ArrivalTime,
+			}
+			gantt = append(gantt, TimeSlice{
+				PID:   processes[i].ProcessID,
+				Start: start,
+				Stop:  completion,
+				CPU:   cpu,
+			})
+		}
+	}
+
+	result := newScheduleResult(gantt, schedule)
+	result.GangDelays = delays
+
+	return result
+}
+
+//endregion
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGangSchedule(t *testing.T) {
+	t.Parallel()
+	// C takes a CPU at 0, so the gang of A and B has to wait for both CPUs until C is done.
+	processes := []Process{
+		{ProcessID: "C", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 3, GroupID: "G"},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 2, GroupID: "G"},
+	}
+	var w bytes.Buffer
+	got := GangSchedule(&w, "Gang", processes, 2)
+
+	wantGantt := []TimeSlice{
+		{PID: "C", Start: 0, Stop: 4},
+		{PID: "A", Start: 4, Stop: 7},
+		{PID: "B", Start: 4, Stop: 6, CPU: 1},
+	}
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+	wantDelays := []GangDelay{{GroupID: "G", Ready: 1, Start: 4, CPUs: 2}}
+	if diff := cmp.Diff(got.GangDelays, wantDelays); diff != "" {
+		t.Errorf(diff)
+	}
+	if want := "Group G waited from 1 to 4 for 2 CPUs\n"; !strings.HasSuffix(w.String(), want) {
+		t.Errorf("missing %q in:\n%s", want, w.String())
+	}
+}
+
+func TestGangSchedule_startTogether(t *testing.T) {
+	t.Parallel()
+	got := gangFirstComeFirstServe([]Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, GroupID: "G"},
+		{ProcessID: "P1", ArrivalTime: 2, BurstDuration: 1, GroupID: "G"},
+	}, 2)
+	wantGantt := []TimeSlice{
+		{PID: "P0", Start: 2, Stop: 7},
+		{PID: "P1", Start: 2, Stop: 3, CPU: 1},
+	}
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+	// Waiting for a member to arrive is not waiting for CPUs.
+	if len(got.GangDelays) != 0 {
+		t.Errorf("GangDelays = %v, want none", got.GangDelays)
+	}
+}
+
+func TestGangSchedule_tooLarge(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	GangSchedule(&w, "Gang", []Process{
+		{ProcessID: "P0", BurstDuration: 1, GroupID: "G"},
+		{ProcessID: "P1", BurstDuration: 1, GroupID: "G"},
+	}, 1)
+	if want := "invalid process: group \"G\" has 2 processes but there are only 1 CPUs\n"; w.String() != want {
+		t.Errorf("got %q, want %q", w.String(), want)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+//region Workload generation
+
+// GenOptions bounds the processes GenerateProcesses makes. Every range is inclusive,
+// and a zero field takes its default, so GenOptions{} gives a busy but unsaturated workload.
+type GenOptions struct {
+	// MinArrival and MaxArrival bound arrival times, 0 to 5n-1 by default for n processes.
+	MinArrival, MaxArrival int64
+	// MinBurst and MaxBurst bound burst durations, 1 to 20 by default. A MinBurst below 1 counts as 1.
+	MinBurst, MaxBurst int64
+	// MinPriority and MaxPriority bound priorities, 1 to 50 by default if both are 0.
+	MinPriority, MaxPriority int64
+}
+
+// GenerateProcesses returns n random processes with IDs P1 to Pn, drawn within opts from a generator seeded with seed,
+// so the same arguments always give the same processes. A maximum below its minimum counts as the minimum.
+func GenerateProcesses(n int, seed int64, opts GenOptions) []Process {
+	if n <= 0 {
+		return nil
+	}
+	if opts.MaxArrival == 0 {
+		opts.MaxArrival = int64(n)*5 - 1
+	}
+	opts.MinBurst = max(opts.MinBurst, 1)
+	if opts.MaxBurst == 0 {
+		opts.MaxBurst = 20
+	}
+	if opts.MinPriority == 0 && opts.MaxPriority == 0 {
+		opts.MinPriority, opts.MaxPriority = 1, 50
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	between := func(lo, hi int64) int64 {
+		if hi <= lo {
+			return lo
+		}
+		return lo + rng.Int63n(hi-lo+1)
+	}
+	processes := make([]Process, n)
+	for i := range processes {
+		processes[i] = Process{
+			ProcessID:     fmt.Sprintf("P%d", i+1),
+			ArrivalTime:   between(opts.MinArrival, opts.MaxArrival),
+			BurstDuration: between(opts.MinBurst, opts.MaxBurst),
+			Priority:      between(opts.MinPriority, opts.MaxPriority),
+		}
+	}
+
+	return processes
+}
+
+// JitterTrials is the outcome of SimulateArrivalJitter: the average wait of each trial,
+// and the mean and population standard deviation of those averages.
+type JitterTrials struct {
+	AveWaits []float64
+	Mean     float64
+	StdDev   float64
+}
+
+// SimulateArrivalJitter schedules trials copies of processes with scheduler, each with every arrival moved by
+// a random amount from -jitter to jitter, and never below 0, to show how sensitive its average wait is to timing noise.
+// The offsets come from a generator seeded with seed, so the same arguments always give the same trials.
+// processes is not modified, and fewer than one trial gives an empty JitterTrials.
+func SimulateArrivalJitter(processes []Process, scheduler Scheduler, jitter int64, trials int, seed int64) JitterTrials {
+	var result JitterTrials
+	if trials <= 0 {
+		return result
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	result.AveWaits = make([]float64, trials)
+	for i := range result.AveWaits {
+		result.AveWaits[i] = scheduler.Schedule(jitterArrivals(processes, jitter, rng)).AveWait
+		result.Mean += result.AveWaits[i]
+	}
+	result.Mean /= float64(trials)
+	for _, wait := range result.AveWaits {
+		result.StdDev += (wait - result.Mean) * (wait - result.Mean)
+	}
+	result.StdDev = math.Sqrt(result.StdDev / float64(trials))
+
+	return result
+}
+
+// jitterArrivals returns a copy of processes with each arrival moved by a draw from rng of -jitter to jitter, clamped at 0.
+func jitterArrivals(processes []Process, jitter int64, rng *rand.Rand) []Process {
+	jittered := make([]Process, len(processes))
+	copy(jittered, processes)
+	if jitter <= 0 {
+		return jittered
+	}
+	for i := range jittered {
+		jittered[i].ArrivalTime = max(jittered[i].ArrivalTime+rng.Int63n(2*jitter+1)-jitter, 0)
+	}
+
+	return jittered
+}
+
+//endregion
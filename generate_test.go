@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		opts GenOptions
+	}{
+		{name: "defaults"},
+		{name: "ranges", opts: GenOptions{MinArrival: 10, MaxArrival: 20, MinBurst: 3, MaxBurst: 5, MinPriority: -2, MaxPriority: 2}},
+		{name: "single values", opts: GenOptions{MinArrival: 7, MaxArrival: 7, MinBurst: 4, MaxBurst: 4, MinPriority: 9, MaxPriority: 9}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := GenerateProcesses(200, 42, tt.opts)
+			if diff := cmp.Diff(got, GenerateProcesses(200, 42, tt.opts)); diff != "" {
+				t.Errorf("same seed gave different processes: %s", diff)
+			}
+			if cmp.Equal(got, GenerateProcesses(200, 43, tt.opts)) && tt.name != "single values" {
+				t.Errorf("different seeds gave the same processes")
+			}
+			if err := ValidateProcesses(got); err != nil {
+				t.Errorf("ValidateProcesses() = %v", err)
+			}
+
+			want := GenOptions{MinArrival: 0, MaxArrival: 999, MinBurst: 1, MaxBurst: 20, MinPriority: 1, MaxPriority: 50}
+			if tt.opts != (GenOptions{}) {
+				want = tt.opts
+			}
+			for i, p := range got {
+				if id := fmt.Sprintf("P%d", i+1); p.ProcessID != id {
+					t.Errorf("process %d has ID %q, want %q", i, p.ProcessID, id)
+				}
+				if p.ArrivalTime < want.MinArrival || p.ArrivalTime > want.MaxArrival ||
+					p.BurstDuration < want.MinBurst || p.BurstDuration > want.MaxBurst ||
+					p.Priority < want.MinPriority || p.Priority > want.MaxPriority {
+					t.Errorf("%+v is outside %+v", p, want)
+				}
+			}
+		})
+	}
+
+	if got := GenerateProcesses(0, 1, GenOptions{}); got != nil {
+		t.Errorf("GenerateProcesses(0) = %v, want nil", got)
+	}
+}
+
+func TestSimulateArrivalJitter(t *testing.T) {
+	t.Parallel()
+	processes := GenerateProcesses(20, 1, GenOptions{})
+	original := append([]Process{}, processes...)
+
+	got := SimulateArrivalJitter(processes, FCFS{}, 3, 10, 7)
+	if diff := cmp.Diff(got, SimulateArrivalJitter(processes, FCFS{}, 3, 10, 7)); diff != "" {
+		t.Errorf("same seed gave different trials: %s", diff)
+	}
+	if cmp.Equal(got, SimulateArrivalJitter(processes, FCFS{}, 3, 10, 8)) {
+		t.Errorf("different seeds gave the same trials")
+	}
+	if len(got.AveWaits) != 10 || got.StdDev <= 0 {
+		t.Errorf("got %d trials with std dev %v, want 10 that vary", len(got.AveWaits), got.StdDev)
+	}
+	if diff := cmp.Diff(processes, original); diff != "" {
+		t.Errorf("processes was modified: %s", diff)
+	}
+
+	// Without jitter every trial is the schedule itself.
+	still := SimulateArrivalJitter(processes, FCFS{}, 0, 3, 7)
+	want := FCFS{}.Schedule(processes).AveWait
+	if diff := cmp.Diff(still, JitterTrials{AveWaits: []float64{want, want, want}, Mean: want}); diff != "" {
+		t.Errorf(diff)
+	}
+
+	if got := SimulateArrivalJitter(processes, FCFS{}, 3, 0, 7); got.AveWaits != nil {
+		t.Errorf("no trials ran %v", got.AveWaits)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, p := range jitterArrivals(GenerateProcesses(100, 1, GenOptions{MaxArrival: 2}), 10, rng) {
+		if p.ArrivalTime < 0 {
+			t.Fatalf("%q jittered to a negative arrival %d", p.ProcessID, p.ArrivalTime)
+		}
+	}
+}
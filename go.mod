@@ -1,5 +1,10 @@
-module main
+module github.com/bbajagain1/Project1
 
 go 1.22.0
 
-require github.com/google/go-cmp v0.6.0
+require (
+	github.com/google/go-cmp v0.6.0
+	github.com/olekukonko/tablewriter v0.0.5
+)
+
+require github.com/mattn/go-runewidth v0.0.9 // indirect
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// update regenerates the golden files instead of comparing against them: go test -run Golden -update
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// assertGolden compares got with testdata/name.golden, or rewrites the file when -update is set.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	golden := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("%s differs from the golden file (-want +got):\n%s", golden, diff)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	t.Parallel()
+	f, err := os.Open("example_processes.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	processes, err := ParseProcessesCSV(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		schedule func(io.Writer, string, []Process, ...Option) ScheduleResult
+		title    string
+	}{
+		{name: "fcfs", schedule: FCFSSchedule, title: "First-come, first-serve"},
+		{name: "sjf", schedule: SJFSchedule, title: "Shortest-job-first"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			tt.schedule(&w, tt.title, processes)
+			assertGolden(t, tt.name, w.Bytes())
+		})
+	}
+}
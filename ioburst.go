@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+//region I/O bursts
+
+// BurstKind is whether a burst segment runs on the CPU or waits on I/O.
+type BurstKind int
+
+const (
+	CPUBurst BurstKind = iota
+	IOBurst
+)
+
+func (k BurstKind) String() string {
+	switch k {
+	case CPUBurst:
+		return "CPU"
+	case IOBurst:
+		return "I/O"
+	default:
+		return fmt.Sprintf("BurstKind(%d)", int(k))
+	}
+}
+
+// BurstSegment is one stretch of a process's CPU or I/O activity.
+type BurstSegment struct {
+	Kind     BurstKind
+	Duration int64
+}
+
+// segments returns the burst segments of p, a single CPU segment if it has none.
+func segments(p Process) []BurstSegment {
+	if len(p.Bursts) > 0 {
+		return p.Bursts
+	}
+	return []BurstSegment{{Kind: CPUBurst, Duration: p.BurstDuration}}
+}
+
+// FCFSIOSchedule outputs and returns a first-come, first-serve schedule of processes that alternate CPU and I/O.
+// A process runs its CPU segment to the end, then does its I/O off the CPU while the next ready process runs,
+// rejoining the back of the ready queue when the I/O completes. Every process can do I/O at once.
+// The gantt chart shows only CPU time. Wait is the time spent in the ready queue, so it excludes I/O,
+// which each row gives as Blocked.
+func FCFSIOSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	if !schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := firstComeFirstServeIO(processes)
+	result.Title = title
+	outputResult(w, result)
+
+	return result
+}
+
+// ioEvent is a process becoming ready to start its next segment.
+type ioEvent struct {
+	at int64
+	i  int
+}
+
+func firstComeFirstServeIO(processes []Process) ScheduleResult {
+	var serviceTime int64
+	schedule := make([]ScheduleRow, len(processes))
+	gantt := make([]TimeSlice, 0)
+
+	// Each process is on segment next[i], and starts it at an event. Events are taken in time order, ties first come first.
+	next := make([]int, len(processes))
+	firstStart := make([]int64, len(processes))
+	events := make([]ioEvent, len(processes))
+	for i := range processes {
+		firstStart[i] = -1
+		events[i] = ioEvent{at: processes[i].ArrivalTime, i: i}
+	}
+
+	complete := func(i int, completion int64) {
+		var ioTime int64
+		for _, segment := range segments(processes[i]) {
+			if segment.Kind == IOBurst {
+				ioTime += segment.Duration
+			}
+		}
+		turnaround := completion - processes[i].ArrivalTime
+		response := firstStart[i] - processes[i].ArrivalTime
+		schedule[i] = ScheduleRow{
+			ProcessID:     processes[i].ProcessID,
+			Priority:      processes[i].Priority,
+			BurstDuration: processes[i].BurstDuration,
+			ArrivalTime:   processes[i].ArrivalTime,
+			Wait:          turnaround - processes[i].BurstDuration - ioTime,
+			Turnaround:    turnaround,
+			Completion:    completion,
+			Response:      response,
+			Blocked:       ioTime,
+		}
+	}
+
+	var ready []int
+	for len(events) > 0 || len(ready) > 0 {
+		// Start every segment due by now: I/O goes straight off the CPU, CPU work joins the ready queue.
+		for {
+			e := -1
+			for j := range events {
+				if events[j].at <= serviceTime && (e < 0 || events[j].at < events[e].at) {
+					e = j
+				}
+			}
+			if e < 0 {
+				break
+			}
+			event := events[e]
+			events = append(events[:e], events[e+1:]...)
+
+			segs := segments(processes[event.i])
+			if next[event.i] == len(segs) {
+				complete(event.i, event.at)
+				continue
+			}
+			if segs[next[event.i]].Kind == IOBurst {
+				events = append(events, ioEvent{at: event.at + segs[next[event.i]].Duration, i: event.i})
+				next[event.i]++
+				continue
+			}
+			ready = append(ready, event.i)
+		}
+
+		if len(ready) == 0 {
+			if len(events) == 0 {
+				break
+			}
+			// No available jobs, jump to the next arrival or I/O completion.
+			serviceTime = events[0].at
+			for _, event := range events {
+				if event.at < serviceTime {
+					serviceTime = event.at
+				}
+			}
+			continue
+		}
+
+		i := ready[0]
+		ready = ready[1:]
+		start := serviceTime
+		serviceTime += segments(processes[i])[next[i]].Duration
+		next[i]++
+		if firstStart[i] < 0 {
+			firstStart[i] = start
+		}
+		if last := len(gantt) - 1; last >= 0 && gantt[last].PID == processes[i].ProcessID && gantt[last].Stop == start {
+			gantt[last].Stop = serviceTime
+		} else {
+			gantt = append(gantt, TimeSlice{
+				PID:   processes[i].ProcessID,
+				Start: start,
+				Stop:  serviceTime,
+			})
+		}
+		events = append(events, ioEvent{at: serviceTime, i: i})
+	}
+
+	return newScheduleResult(gantt, schedule)
+}
+
+//endregion
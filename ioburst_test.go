@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_firstComeFirstServeIO(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantRows  []ScheduleRow
+	}{
+		{
+			name: "another process fills the I/O gap",
+			processes: []Process{
+				{ProcessID: "A", BurstDuration: 3, Bursts: []BurstSegment{
+					{Kind: CPUBurst, Duration: 2},
+					{Kind: IOBurst, Duration: 4},
+					{Kind: CPUBurst, Duration: 1},
+				}},
+				{ProcessID: "B", BurstDuration: 3},
+			},
+			wantGantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 2},
+				{PID: "B", Start: 2, Stop: 5},
+				{PID: "A", Start: 6, Stop: 7},
+			},
+			wantRows: []ScheduleRow{
+				{ProcessID: "A", BurstDuration: 3, Turnaround: 7, Completion: 7, Blocked: 4},
+				{ProcessID: "B", BurstDuration: 3, Wait: 2, Turnaround: 5, Completion: 5, Response: 2},
+			},
+		},
+		{
+			name: "returning from I/O waits for the running process",
+			processes: []Process{
+				{ProcessID: "A", BurstDuration: 2, Bursts: []BurstSegment{
+					{Kind: CPUBurst, Duration: 1},
+					{Kind: IOBurst, Duration: 1},
+					{Kind: CPUBurst, Duration: 1},
+				}},
+				{ProcessID: "B", BurstDuration: 3},
+			},
+			wantGantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 1},
+				{PID: "B", Start: 1, Stop: 4},
+				{PID: "A", Start: 4, Stop: 5},
+			},
+			wantRows: []ScheduleRow{
+				{ProcessID: "A", BurstDuration: 2, Wait: 2, Turnaround: 5, Completion: 5, Blocked: 1},
+				{ProcessID: "B", BurstDuration: 3, Wait: 1, Turnaround: 4, Completion: 4, Response: 1},
+			},
+		},
+		{
+			name: "ending on I/O",
+			processes: []Process{
+				{ProcessID: "A", BurstDuration: 1, Bursts: []BurstSegment{
+					{Kind: CPUBurst, Duration: 1},
+					{Kind: IOBurst, Duration: 3},
+				}},
+			},
+			wantGantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 1},
+			},
+			wantRows: []ScheduleRow{
+				{ProcessID: "A", BurstDuration: 1, Turnaround: 4, Completion: 4, Blocked: 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := firstComeFirstServeIO(tt.processes)
+			if diff := cmp.Diff(got.Gantt, tt.wantGantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(got.Rows, tt.wantRows); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
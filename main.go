@@ -2,127 +2,178 @@ package main
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"log"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/olekukonko/tablewriter"
 )
 
 func main() {
 	// parse args.
 	flagSet := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
-	scheduler, data, err := parseCLI(flagSet, os.Args[1:])
+	scheduler, data, opts, err := parseCLI(flagSet, os.Args[1:])
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stdout, err)
 		flagSet.PrintDefaults()
 		os.Exit(1)
 	}
 
+	// NO_COLOR turns colour off whatever -color says.
+	if os.Getenv("NO_COLOR") != "" {
+		opts = append(opts, WithGanttOptions(NoColor()))
+	}
+
 	// Load and parse processes.
-	processes, err := loadProcesses(data)
+	processes, err := ParseProcessesCSV(data)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// Run the given scheduler.
-	switch scheduler {
-	case fcfs:
-		FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
-	case sjf:
-		SJFSchedule(os.Stdout, "Shortest-job-first", processes)
-	case sjfp:
-		SJFPrioritySchedule(os.Stdout, "Priority", processes)
-	case rr:
-		RRSchedule(os.Stdout, "Round-robin", processes)
-	}
+	// Run the given algorithm.
+	run(os.Stdout, scheduler, processes, opts...)
 }
 
-//go:generate stringer -type=Scheduler
-type Scheduler uint
+// rrQuantum is the default time quantum used for round-robin scheduling.
+const rrQuantum int64 = 1
 
-const (
-	fcfs Scheduler = iota + 1
-	sjf
-	sjfp
-	rr
-)
+// run outputs and returns scheduler's schedule of processes drawn as opts say,
+// or explains to w why processes cannot be scheduled.
+func run(w io.Writer, scheduler Scheduler, processes []Process, opts ...Option) ScheduleResult {
+	if !schedulable(w, processes) {
+		return ScheduleResult{}
+	}
+	result := scheduler.Schedule(processes)
+	newOptions(opts).output(w, result)
+
+	return result
+}
 
-func parseCLI(flagSet *flag.FlagSet, args []string) (cmd Scheduler, data io.Reader, err error) {
-	fcfsFlag := flagSet.Bool(fcfs.String(), false, "First-come, first-serve scheduling")
-	sjfFlag := flagSet.Bool(sjf.String(), false, "Shortest-job-first scheduling")
-	sjfpFlag := flagSet.Bool(sjfp.String(), false, "Shortest-job-first with priority scheduling")
-	rrFlag := flagSet.Bool(rr.String(), false, "Round-robin scheduling")
+// parseCLI parses args into the scheduler to run, built by NewScheduler from the -algorithm name and the flags
+// for its parameters, the process data, and the options drawing the output.
+func parseCLI(flagSet *flag.FlagSet, args []string) (scheduler Scheduler, data io.Reader, opts []Option, err error) {
+	algorithm := flagSet.String("algorithm", "", "Scheduling algorithm: "+strings.Join(SchedulerNames(), "|"))
+	quantum := flagSet.Int64("quantum", rrQuantum, "Time quantum for round-robin scheduling")
+	preemptive := flagSet.Bool("preemptive", false, "Preempt the running process for a higher priority arrival in priority scheduling")
+	input := flagSet.String("input", "", "Path to the process CSV; defaults to the last argument or stdin")
+	scale := flagSet.Int64("scale", 0, "Time units per character of the gantt chart; 0 draws every block the same width")
+	width := flagSet.Int("width", 0, "Widest line of the gantt chart before it wraps; 0 never wraps")
+	ticks := flagSet.Int64("ticks", -1, "Draw tick marks under the gantt chart every n time units; 0 marks only block boundaries, -1 none")
+	legend := flagSet.Bool("legend", false, "Name each process once in a legend under the gantt chart")
+	columns := flagSet.String("columns", "", "Comma-separated columns of the schedule table, such as id,wait,response; defaults to all but response and response-ratio")
+	sortRows := flagSet.String("sort", "input", "Order of the schedule table's rows: input|id|completion|arrival")
+	decimals := flagSet.Int("decimals", 2, "Digits after the decimal point of the averages under the schedule table")
+	trim := flagSet.Bool("trim", false, "Drop trailing zeros from the averages under the schedule table")
+	color := flagSet.String("color", "auto", "When to colour the gantt chart: auto|always|never")
 	if err := flagSet.Parse(args); err != nil {
-		return 0, nil, err
-	}
-	// validate only one flag is set
-	var count int
-	if *fcfsFlag {
-		count++
-		cmd = fcfs
-	}
-	if *sjfFlag {
-		count++
-		cmd = sjf
-	}
-	if *sjfpFlag {
-		count++
-		cmd = sjfp
-	}
-	if *rrFlag {
-		count++
-		cmd = rr
-	}
-	switch count {
-	case 0:
-		return 0, nil, fmt.Errorf("one scheduler flag must be set")
-	case 1:
-		// validate that data file is piped in.
-		if data, err := readData(os.Args[:2]); err != nil {
-			return 0, nil, err
-		} else {
-			return cmd, data, nil
+		return nil, nil, nil, err
+	}
+
+	if *algorithm == "" {
+		return nil, nil, nil, fmt.Errorf("%w: -algorithm must be set", ErrInvalidArgs)
+	}
+	scheduler, err = NewScheduler(*algorithm, map[string]string{
+		"quantum":    strconv.FormatInt(*quantum, 10),
+		"preemptive": strconv.FormatBool(*preemptive),
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var gantt []GanttOption
+	switch *color {
+	case "auto":
+		gantt = append(gantt, WithColor(ColorAuto))
+	case "always":
+		gantt = append(gantt, WithColor(ColorAlways))
+	case "never":
+		gantt = append(gantt, WithColor(ColorNever))
+	default:
+		return nil, nil, nil, fmt.Errorf("%w: -color must be auto, always, or never", ErrInvalidArgs)
+	}
+	switch {
+	case *scale < 0:
+		return nil, nil, nil, fmt.Errorf("%w: -scale must not be negative", ErrInvalidArgs)
+	case *scale > 0:
+		gantt = append(gantt, WithScale(*scale))
+	}
+	switch {
+	case *width < 0:
+		return nil, nil, nil, fmt.Errorf("%w: -width must not be negative", ErrInvalidArgs)
+	case *width > 0:
+		gantt = append(gantt, WithMaxWidth(*width))
+	}
+	switch {
+	case *ticks < -1:
+		return nil, nil, nil, fmt.Errorf("%w: -ticks must be at least -1", ErrInvalidArgs)
+	case *ticks >= 0:
+		gantt = append(gantt, WithTicks(*ticks))
+	}
+	if *legend {
+		gantt = append(gantt, WithLegend())
+	}
+
+	opts = []Option{WithGanttOptions(gantt...)}
+	if *columns != "" {
+		set, err := parseColumns(*columns)
+		if err != nil {
+			return nil, nil, nil, err
 		}
+		opts = append(opts, WithColumns(set))
+	}
+	switch *sortRows {
+	case "input":
+	case "id":
+		opts = append(opts, WithRowOrder(RowsByID))
+	case "completion":
+		opts = append(opts, WithRowOrder(RowsByCompletion))
+	case "arrival":
+		opts = append(opts, WithRowOrder(RowsByArrival))
 	default:
-		return 0, nil, fmt.Errorf("only one scheduler flag must be set")
+		return nil, nil, nil, fmt.Errorf("%w: -sort must be input, id, completion, or arrival", ErrInvalidArgs)
 	}
-}
+	if *decimals < 0 {
+		return nil, nil, nil, fmt.Errorf("%w: -decimals must not be negative", ErrInvalidArgs)
+	}
+	figures := []ScheduleOption{WithDecimals(*decimals)}
+	if *trim {
+		figures = append(figures, WithTrimZeros())
+	}
+	opts = append(opts, WithScheduleOptions(figures...))
 
-func readData(args []string) (io.Reader, error) {
-	fi, _ := os.Stdin.Stat()
-	if (fi.Mode() & os.ModeCharDevice) == 0 {
-		return os.Stdin, nil
-	} else if len(args) == 1 {
-		return nil, fmt.Errorf("scheduler data must be passed in or file given as last argument")
+	path := *input
+	if path == "" {
+		path = flagSet.Arg(0)
 	}
-	r, err := os.Open(os.Args[2])
-	if err != nil {
-		return nil, fmt.Errorf("%w: error opening data file", err)
+	if data, err = readData(path); err != nil {
+		return nil, nil, nil, err
 	}
 
-	return r, nil
+	return scheduler, data, opts, nil
 }
 
-func openProcessingFile(args ...string) (*os.File, func(), error) {
-	if len(args) != 2 {
-		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
+// readData opens the process file at path or, when path is empty, reads data piped to stdin.
+func readData(path string) (io.Reader, error) {
+	if path == "" {
+		fi, _ := os.Stdin.Stat()
+		if (fi.Mode() & os.ModeCharDevice) == 0 {
+			return os.Stdin, nil
+		}
+		return nil, fmt.Errorf("%w: scheduler data must be piped in or given with -input", ErrInvalidArgs)
 	}
-	// Read in CSV process CSV file
-	f, err := os.Open(args[1])
+	r, err := os.Open(path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
-	}
-	closeFn := func() {
-		if err := f.Close(); err != nil {
-			log.Fatalf("%v: error closing scheduling file", err)
-		}
+		return nil, fmt.Errorf("%w: error opening data file", err)
 	}
 
-	return f, closeFn, nil
+	return r, nil
 }
 
 //region Output helpers
@@ -133,86 +184,913 @@ func outputTitle(w io.Writer, title string) {
 	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
 }
 
-func outputGantt(w io.Writer, gantt []TimeSlice) {
+const (
+	// idleLabel labels a gantt block where the CPU had nothing to run.
+	idleLabel = "idle"
+	// switchLabel marks a gantt block of context-switch overhead.
+	switchLabel = "cs"
+)
+
+func blockLabel(block TimeSlice) string {
+	switch {
+	case block.Switch:
+		return switchLabel
+	case block.Idle:
+		return idleLabel
+	}
+	return block.PID
+}
+
+// String writes s as its label and the half-open interval it covers, such as "P1 [0,4)":
+// it starts at Start and runs up to but not including Stop. A context switch is labelled "cs",
+// and a slice on a CPU other than the first names it, as in "P1 [0,4) on CPU 1".
+func (s TimeSlice) String() string {
+	str := fmt.Sprintf("%s [%d,%d)", blockLabel(s), s.Start, s.Stop)
+	if s.CPU != 0 {
+		str += fmt.Sprintf(" on CPU %d", s.CPU)
+	}
+
+	return str
+}
+
+// FormatTimeSlices writes each of slices as TimeSlice.String does, separated by commas.
+func FormatTimeSlices(slices []TimeSlice) string {
+	strs := make([]string, len(slices))
+	for i, slice := range slices {
+		strs[i] = slice.String()
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// GanttOption configures how outputGantt draws a chart.
+type GanttOption func(*ganttOptions)
+
+type ganttOptions struct {
+	maxWidth  int
+	scale     int64
+	ticks     bool
+	tickEvery int64
+	colorMode ColorMode
+	noColor   bool
+	legend    bool
+	// color is whether the chart is being drawn in colour, settled from colorMode, noColor, and the writer.
+	color bool
+	// preemptive is whether any slice in the chart was preempted, so completions need marking apart.
+	preemptive bool
+}
+
+// WithScale draws each block as one character per scale time units, rounded to the nearest character, instead of
+// giving every block the same width. A block always gets at least one character, so very short slices stay visible.
+func WithScale(scale int64) GanttOption {
+	return func(o *ganttOptions) {
+		o.scale = scale
+	}
+}
+
+// completedBar ends a process's block in place of "|" when it ran to completion, in a chart with preempted slices.
+const completedBar = "]"
+
+// ganttContinued ends a line of a wrapped gantt chart that continues on the next line.
+const ganttContinued = "..."
+
+// WithMaxWidth wraps the chart so no line is wider than width characters, breaking between blocks.
+// Each line but the last ends with "...", and each line's times carry on from where the one before left off.
+// A single block wider than width still gets a line to itself.
+func WithMaxWidth(width int) GanttOption {
+	return func(o *ganttOptions) {
+		o.maxWidth = width
+	}
+}
+
+// WithTicks draws a line of tick marks between the chart and its times: a '+' under every block boundary and,
+// if every is greater than 0, a '\” at each multiple of every inside a block, which is also labelled with its time when there is room.
+func WithTicks(every int64) GanttOption {
+	return func(o *ganttOptions) {
+		o.ticks = true
+		o.tickEvery = every
+	}
+}
+
+// WithLegend writes a line under the chart naming each process once, in the order they first appear in it,
+// in its colour if the chart is coloured. A process whose label is cut short somewhere in the chart, as WithScale can do,
+// is given as the shortest label drawn for it and its full ProcessID, as in "Lo = LongJob".
+func WithLegend() GanttOption {
+	return func(o *ganttOptions) {
+		o.legend = true
+	}
+}
+
+// ColorMode is when WithColor colours a gantt chart.
+type ColorMode int
+
+const (
+	// ColorNever draws the chart in plain text, the default.
+	ColorNever ColorMode = iota
+	// ColorAuto colours the chart only when it is written straight to a terminal.
+	ColorAuto
+	// ColorAlways colours the chart whatever it is written to.
+	ColorAlways
+)
+
+// WithColor draws each process's block in an ANSI colour picked by hashing its PID, so a process keeps its colour
+// from chart to chart, and idle blocks in dim grey. NoColor overrides it.
+func WithColor(mode ColorMode) GanttOption {
+	return func(o *ganttOptions) {
+		o.colorMode = mode
+	}
+}
+
+// NoColor draws the chart in plain text even if WithColor is also given.
+func NoColor() GanttOption {
+	return func(o *ganttOptions) {
+		o.noColor = true
+	}
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiIdle  = "\x1b[2;37m"
+)
+
+// bar returns the bar that ends block: completedBar for a process that finished in a chart with preempted slices,
+// and "|" otherwise.
+func (o ganttOptions) bar(block TimeSlice) string {
+	if o.preemptive && !block.Switch && !block.Preempted && !block.Idle {
+		return completedBar
+	}
+	return "|"
+}
+
+// ansiPalette is the foreground colours processes are spread across: red, green, yellow, blue, magenta, and cyan.
+var ansiPalette = []string{"\x1b[31m", "\x1b[32m", "\x1b[33m", "\x1b[34m", "\x1b[35m", "\x1b[36m"}
+
+// blockColor returns the escape code that starts block's colour, or "" for a context switch.
+func blockColor(block TimeSlice) string {
+	switch {
+	case block.Switch:
+		return ""
+	case block.Idle:
+		return ansiIdle
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(block.PID))
+
+	return ansiPalette[h.Sum32()%uint32(len(ansiPalette))]
+}
+
+// isTerminal reports whether w is a file open on a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// outputGantt draws the gantt chart, adding an idle block for any time the CPU had nothing to run.
+// A chart across several CPUs is drawn as a row per CPU.
+// If any slice was preempted, a process's block that ran to completion ends in "]" rather than "|",
+// so the bars a quantum expired at stand out from the ones a process finished at.
+func outputGantt(w io.Writer, gantt []TimeSlice, opts ...GanttOption) {
+	var o ganttOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.color = !o.noColor && (o.colorMode == ColorAlways || o.colorMode == ColorAuto && isTerminal(w))
+	for _, slice := range gantt {
+		o.preemptive = o.preemptive || slice.Preempted
+	}
+
 	_, _ = fmt.Fprintln(w, "Gantt schedule")
 
-	buffer := 2
-	widest := 0
+	cpus := cpuCount(gantt)
+	if cpus == 1 {
+		outputGanttRow(w, gantt, o)
+		return
+	}
+	rows := make([][]TimeSlice, cpus)
 	for _, slice := range gantt {
-		if len(slice.PID) > widest {
-			widest = len(slice.PID)
+		rows[slice.CPU] = append(rows[slice.CPU], slice)
+	}
+	outputGanttMulti(w, rows, o)
+}
+
+// outputGanttMulti draws a labelled row per CPU above one shared time axis, so that a column is the same instant
+// on every CPU. Every time any CPU changes what it runs is a boundary on all of them, and a block spans
+// the columns of all the boundaries inside it. A CPU with nothing to run is blank.
+// The rows are not wrapped and have no ticks, so WithMaxWidth and WithTicks only apply to a single CPU.
+func outputGanttMulti(w io.Writer, rows [][]TimeSlice, o ganttOptions) {
+	var end int64
+	times := map[int64]bool{0: true}
+	for _, row := range rows {
+		for _, slice := range row {
+			times[slice.Start], times[slice.Stop] = true, true
+			end = max(end, slice.Stop)
 		}
 	}
+	boundaries := make([]int64, 0, len(times))
+	for t := range times {
+		boundaries = append(boundaries, t)
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i] < boundaries[j] })
+	index := make(map[int64]int, len(boundaries))
+	for i, t := range boundaries {
+		index[t] = i
+	}
 
-	_, _ = fmt.Fprintf(w, "|")
-	last := gantt[0].Start
+	// columns[i] is the column of the bar at boundaries[i], counting from the first bar.
+	columns := make([]int, len(boundaries))
+	widest := 1
+	for _, row := range rows {
+		for _, slice := range row {
+			widest = max(widest, len(blockLabel(slice)))
+		}
+	}
+	for i := 1; i < len(boundaries); i++ {
+		width := widest + 4
+		if o.scale > 0 {
+			// Round each boundary rather than each block, so rounding never drifts along the chart.
+			width = max(int((boundaries[i]+o.scale/2)/o.scale-(boundaries[i-1]+o.scale/2)/o.scale), 1)
+		}
+		columns[i] = columns[i-1] + width + 1
+	}
+
+	label := func(cpu int) string { return fmt.Sprintf("CPU %-*d ", len(fmt.Sprint(len(rows)-1)), cpu) }
+	var (
+		drawn []TimeSlice
+		cells []string
+	)
+	for cpu, row := range rows {
+		blocks := ganttBlocks(row)
+		if last := len(blocks) - 1; last < 0 || blocks[last].Stop < end {
+			start := int64(0)
+			if last >= 0 {
+				start = blocks[last].Stop
+			}
+			blocks = append(blocks, TimeSlice{Start: start, Stop: end, CPU: cpu, Idle: true})
+		}
+		_, _ = fmt.Fprint(w, label(cpu)+"|")
+		for _, block := range blocks {
+			text := ""
+			if !block.Idle {
+				text = blockLabel(block)
+			}
+			cell := fitLabel(text, columns[index[block.Stop]]-columns[index[block.Start]]-1)
+			drawn, cells = append(drawn, block), append(cells, cell)
+			if code := blockColor(block); o.color && code != "" {
+				cell = code + cell + ansiReset
+			}
+			_, _ = fmt.Fprint(w, cell+o.bar(block))
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+
+	// Write each time under its bar, skipping any that would run into the one before.
+	axis := []byte(strings.Repeat(" ", len(label(0))))
+	for i, t := range boundaries {
+		column := len(label(0)) + columns[i]
+		if i > 0 && column <= len(axis) {
+			continue
+		}
+		axis = append(axis, strings.Repeat(" ", column-len(axis))...)
+		axis = append(axis, fmt.Sprint(t)...)
+	}
+	_, _ = fmt.Fprintf(w, "%s\n", axis)
+	outputGanttLegend(w, drawn, cells, o)
+	_, _ = fmt.Fprintln(w)
+}
+
+// ganttBlocks returns the slices of a single CPU's gantt with an idle block filling each gap, starting from time 0.
+func ganttBlocks(gantt []TimeSlice) []TimeSlice {
+	var (
+		blocks []TimeSlice
+		last   int64
+	)
 	for _, slice := range gantt {
-		_, _ = fmt.Fprint(w, strings.Repeat(" ", buffer))
 		if slice.Start > last {
-			_, _ = fmt.Fprint(w, strings.Repeat(" ", widest))
-		} else {
-			_, _ = fmt.Fprint(w, slice.PID)
+			blocks = append(blocks, TimeSlice{Start: last, Stop: slice.Start, CPU: slice.CPU, Idle: true})
 		}
-		_, _ = fmt.Fprint(w, strings.Repeat(" ", buffer)+"|")
+		blocks = append(blocks, slice)
 		last = slice.Stop
 	}
+
+	return blocks
+}
+
+func outputGanttRow(w io.Writer, gantt []TimeSlice, o ganttOptions) {
+	blocks := ganttBlocks(gantt)
+
+	buffer := 2
+	widest := 0
+	for _, block := range blocks {
+		if len(blockLabel(block)) > widest {
+			widest = len(blockLabel(block))
+		}
+	}
+
+	// cells are the text between the bars of each block.
+	cells := make([]string, len(blocks))
+	if o.scale > 0 {
+		// Round each boundary rather than each block, so rounding never drifts along the chart.
+		var end int64
+		for i, block := range blocks {
+			stop := (block.Stop + o.scale/2) / o.scale
+			if stop <= end {
+				stop = end + 1
+			}
+			cells[i] = fitLabel(blockLabel(block), int(stop-end))
+			end = stop
+		}
+	} else {
+		for i, block := range blocks {
+			cells[i] = strings.Repeat(" ", buffer) + fmt.Sprintf("%-*s", widest, blockLabel(block)) + strings.Repeat(" ", buffer)
+		}
+	}
+
+	if len(blocks) == 0 {
+		drawGanttLine(w, nil, nil, o, "")
+		outputGanttLegend(w, nil, nil, o)
+		_, _ = fmt.Fprintf(w, "\n")
+		return
+	}
+	// Break the row into lines of whole blocks that fit in maxWidth, each but the last ending with a marker.
+	for first := 0; first < len(blocks); {
+		end, width := first+1, 1+len(cells[first])+1
+		for ; end < len(blocks); end++ {
+			width += len(cells[end]) + 1
+			limit := o.maxWidth
+			if end < len(blocks)-1 {
+				limit -= len(ganttContinued)
+			}
+			if o.maxWidth > 0 && width > limit {
+				break
+			}
+		}
+		marker := ganttContinued
+		if end == len(blocks) {
+			marker = ""
+		}
+		drawGanttLine(w, blocks[first:end], cells[first:end], o, marker)
+		first = end
+	}
+	outputGanttLegend(w, blocks, cells, o)
 	_, _ = fmt.Fprintf(w, "\n")
-	width := buffer + widest + buffer + 1
-	for i := range gantt {
-		t := fmt.Sprint(gantt[i].Start)
-		_, _ = fmt.Fprint(w, t)
-		_, _ = fmt.Fprint(w, strings.Repeat(" ", width-len(t)))
-		if i == len(gantt)-1 {
-			_, _ = fmt.Fprint(w, gantt[i].Stop)
+}
+
+// outputGanttLegend writes the WithLegend line for blocks drawn as cells, if o asks for one.
+func outputGanttLegend(w io.Writer, blocks []TimeSlice, cells []string, o ganttOptions) {
+	if !o.legend {
+		return
+	}
+	var order []string
+	shortest := make(map[string]string)
+	colours := make(map[string]string)
+	for i, block := range blocks {
+		if block.Switch || block.Idle {
+			continue
+		}
+		drawn := strings.TrimSpace(cells[i])
+		current, ok := shortest[block.PID]
+		if !ok {
+			order = append(order, block.PID)
+			colours[block.PID] = blockColor(block)
+		}
+		if !ok || len(drawn) < len(current) {
+			shortest[block.PID] = drawn
+		}
+	}
+
+	entries := make([]string, len(order))
+	for i, pid := range order {
+		entry := pid
+		if shortest[pid] != pid {
+			entry = shortest[pid] + " = " + pid
+		}
+		if o.color {
+			entry = colours[pid] + entry + ansiReset
+		}
+		entries[i] = entry
+	}
+	_, _ = fmt.Fprintf(w, "Legend: %s\n", strings.Join(entries, ", "))
+}
+
+// drawGanttLine draws one line of gantt blocks with their ticks and times, ending the bars with marker.
+func drawGanttLine(w io.Writer, blocks []TimeSlice, cells []string, o ganttOptions, marker string) {
+	_, _ = fmt.Fprintf(w, "|")
+	for i, cell := range cells {
+		if code := blockColor(blocks[i]); o.color && code != "" {
+			cell = code + cell + ansiReset
+		}
+		_, _ = fmt.Fprint(w, cell+o.bar(blocks[i]))
+	}
+	_, _ = fmt.Fprintf(w, "%s\n", marker)
+
+	// Boundary i is the bar before block i, the last one is the bar after the last block.
+	bounds := make([]int, len(blocks)+1)
+	for i, cell := range cells {
+		bounds[i+1] = bounds[i] + len(cell) + 1
+	}
+
+	marks := make([]ganttMark, 0, len(bounds))
+	for i, block := range blocks {
+		marks = append(marks, ganttMark{column: bounds[i], time: block.Start, boundary: true})
+		if o.tickEvery <= 0 {
+			continue
+		}
+		for t := (block.Start/o.tickEvery + 1) * o.tickEvery; t < block.Stop; t += o.tickEvery {
+			// Place the tick proportionally between the bars, leaving them clear.
+			column := bounds[i] + int((t-block.Start)*int64(bounds[i+1]-bounds[i])/(block.Stop-block.Start))
+			if column > bounds[i] && column < bounds[i+1] {
+				marks = append(marks, ganttMark{column: column, time: t})
+			}
+		}
+	}
+	if len(blocks) > 0 {
+		marks = append(marks, ganttMark{column: bounds[len(blocks)], time: blocks[len(blocks)-1].Stop, boundary: true})
+	}
+
+	if o.ticks && len(blocks) > 0 {
+		line := []byte(strings.Repeat("-", bounds[len(blocks)]+1))
+		for _, mark := range marks {
+			if mark.boundary {
+				line[mark.column] = '+'
+			} else {
+				line[mark.column] = '\''
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s\n", line)
+	}
+
+	// Write each time under its mark, skipping any that would run into the one before.
+	// A tick inside a block is also skipped if it would run into the next boundary, which matters more.
+	var axis []byte
+	for i, mark := range marks {
+		if i > 0 && mark.column <= len(axis) {
+			continue
+		}
+		label := fmt.Sprint(mark.time)
+		if !mark.boundary {
+			next := i + 1
+			for !marks[next].boundary {
+				next++
+			}
+			if mark.column+len(label) >= marks[next].column {
+				continue
+			}
+		}
+		axis = append(axis, strings.Repeat(" ", mark.column-len(axis))...)
+		axis = append(axis, label...)
+	}
+	_, _ = fmt.Fprintf(w, "%s\n", axis)
+}
+
+// ganttMark is a time on the axis of a gantt row and the column it is drawn at.
+type ganttMark struct {
+	column   int
+	time     int64
+	boundary bool
+}
+
+// fitLabel centres label in width characters, cutting it short if it does not fit.
+func fitLabel(label string, width int) string {
+	if len(label) >= width {
+		return label[:width]
+	}
+	pad := width - len(label)
+
+	return strings.Repeat(" ", pad/2) + label + strings.Repeat(" ", pad-pad/2)
+}
+
+// Column identifies a column of the schedule table.
+type Column int
+
+const (
+	ColumnID Column = iota
+	ColumnPriority
+	ColumnBurst
+	ColumnArrival
+	ColumnWait
+	ColumnTurnaround
+	ColumnExit
+	ColumnResponse
+	// ColumnResponseRatio is turnaround / burst, the ratio HRRN had picked the process at.
+	ColumnResponseRatio
+)
+
+// ColumnSet lists the columns of a schedule table, in the order they are drawn.
+type ColumnSet []Column
+
+// DefaultColumns are the columns drawn when no ColumnSet is given.
+var DefaultColumns = ColumnSet{ColumnID, ColumnPriority, ColumnBurst, ColumnArrival, ColumnWait, ColumnTurnaround, ColumnExit}
+
+func (c Column) header() string {
+	switch c {
+	case ColumnID:
+		return "ID"
+	case ColumnPriority:
+		return "Priority"
+	case ColumnBurst:
+		return "Burst"
+	case ColumnArrival:
+		return "Arrival"
+	case ColumnWait:
+		return "Wait"
+	case ColumnTurnaround:
+		return "Turnaround"
+	case ColumnExit:
+		return "Exit"
+	case ColumnResponse:
+		return "Response"
+	case ColumnResponseRatio:
+		return "Response ratio"
+	default:
+		return fmt.Sprintf("Column(%d)", int(c))
+	}
+}
+
+// name is how the -columns flag gives c, its header in lower case with hyphens for spaces, such as "response-ratio".
+func (c Column) name() string {
+	return strings.ReplaceAll(strings.ToLower(c.header()), " ", "-")
+}
+
+// parseColumns parses a comma-separated list of column names, such as "id,wait,response-ratio".
+func parseColumns(list string) (ColumnSet, error) {
+	var columns ColumnSet
+	for _, name := range strings.Split(list, ",") {
+		c := ColumnID
+		for c <= ColumnResponseRatio && c.name() != strings.TrimSpace(name) {
+			c++
+		}
+		if c > ColumnResponseRatio {
+			names := make([]string, 0, ColumnResponseRatio+1)
+			for c := ColumnID; c <= ColumnResponseRatio; c++ {
+				names = append(names, c.name())
+			}
+			return nil, fmt.Errorf("%w: unknown column %q, must be one of: %s", ErrInvalidArgs, name, strings.Join(names, ", "))
+		}
+		columns = append(columns, c)
+	}
+
+	return columns, nil
+}
+
+func (c Column) value(row ScheduleRow) string {
+	switch c {
+	case ColumnID:
+		return row.ProcessID
+	case ColumnPriority:
+		return fmt.Sprint(row.Priority)
+	case ColumnBurst:
+		return fmt.Sprint(row.BurstDuration)
+	case ColumnArrival:
+		return fmt.Sprint(row.ArrivalTime)
+	case ColumnWait:
+		return fmt.Sprint(row.Wait)
+	case ColumnTurnaround:
+		return fmt.Sprint(row.Turnaround)
+	case ColumnExit:
+		return fmt.Sprint(row.Completion)
+	case ColumnResponse:
+		return fmt.Sprint(row.Response)
+	case ColumnResponseRatio:
+		if row.BurstDuration == 0 {
+			return "-"
 		}
+		return fmt.Sprintf("%.2f", float64(row.Turnaround)/float64(row.BurstDuration))
+	default:
+		return ""
 	}
+}
+
+// RowOrder is the order the rows of a schedule table are drawn in.
+type RowOrder int
+
+const (
+	// RowsByInput keeps the rows in the order the processes were given.
+	RowsByInput RowOrder = iota
+	// RowsByID sorts the rows by ProcessID.
+	RowsByID
+	// RowsByCompletion sorts the rows by completion time.
+	RowsByCompletion
+	// RowsByArrival sorts the rows by arrival time.
+	RowsByArrival
+)
 
-	_, _ = fmt.Fprintf(w, "\n\n")
+// SortRows returns a copy of rows in the given order, with ties kept in input order.
+func SortRows(rows []ScheduleRow, order RowOrder) []ScheduleRow {
+	sorted := make([]ScheduleRow, len(rows))
+	copy(sorted, rows)
+	switch order {
+	case RowsByID:
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ProcessID < sorted[j].ProcessID })
+	case RowsByCompletion:
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Completion < sorted[j].Completion })
+	case RowsByArrival:
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ArrivalTime < sorted[j].ArrivalTime })
+	}
+
+	return sorted
+}
+
+// ScheduleOption configures how outputSchedule writes the averages and other figures below the table.
+type ScheduleOption func(*scheduleOptions)
+
+type scheduleOptions struct {
+	decimals  int
+	trimZeros bool
+}
+
+// WithDecimals writes the figures with places digits after the decimal point instead of 2.
+// Places below 0 count as 0.
+func WithDecimals(places int) ScheduleOption {
+	return func(o *scheduleOptions) {
+		o.decimals = max(places, 0)
+	}
 }
 
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
+// WithTrimZeros drops trailing zeros after the decimal point, and the point itself for a whole number,
+// so that exactly 3 is written as 3 rather than 3.00.
+func WithTrimZeros() ScheduleOption {
+	return func(o *scheduleOptions) {
+		o.trimZeros = true
+	}
+}
+
+// format writes v as o says.
+func (o scheduleOptions) format(v float64) string {
+	s := strconv.FormatFloat(v, 'f', o.decimals, 64)
+	if o.trimZeros && strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	return s
+}
+
+// outputSchedule draws the schedule table with the given columns, or DefaultColumns if there are none,
+// and rows in the given order, followed by the averages formatted as opts say.
+func outputSchedule(w io.Writer, result ScheduleResult, columns ColumnSet, order RowOrder, opts ...ScheduleOption) {
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+	o := scheduleOptions{decimals: 2}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	_, _ = fmt.Fprintln(w, "Schedule table")
 	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
-	table.AppendBulk(rows)
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.header()
+	}
+	table.SetHeader(headers)
+	for _, row := range SortRows(result.Rows, order) {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = c.value(row)
+		}
+		table.Append(cells)
+	}
 	table.Render()
 	_, _ = fmt.Fprintln(w)
-	_, _ = fmt.Fprintf(w, "Average wait: %.2f\n", wait)
-	_, _ = fmt.Fprintf(w, "Average turnaround: %.2f\n", turnaround)
-	_, _ = fmt.Fprintf(w, "Throughput: %.2f\n", throughput)
+	_, _ = fmt.Fprintf(w, "Average wait: %s\n", o.format(result.AveWait))
+	_, _ = fmt.Fprintf(w, "Average turnaround: %s\n", o.format(result.AveTurnaround))
+	waitVariance, waitStdDev := WaitSpread(result.Rows)
+	_, turnaroundStdDev := TurnaroundSpread(result.Rows)
+	_, _ = fmt.Fprintf(w, "Wait variance: %s\n", o.format(waitVariance))
+	_, _ = fmt.Fprintf(w, "Wait std dev: %s\n", o.format(waitStdDev))
+	_, _ = fmt.Fprintf(w, "Turnaround std dev: %s\n", o.format(turnaroundStdDev))
+	wait, turnaround := WaitPercentiles(result.Rows), TurnaroundPercentiles(result.Rows)
+	_, _ = fmt.Fprintf(w, "Wait p50/p90/p95/p99: %d/%d/%d/%d\n", wait.P50, wait.P90, wait.P95, wait.P99)
+	_, _ = fmt.Fprintf(w, "Turnaround p50/p90/p95/p99: %d/%d/%d/%d\n", turnaround.P50, turnaround.P90, turnaround.P95, turnaround.P99)
+	_, _ = fmt.Fprintf(w, "Average response: %s\n", o.format(result.AveResponse))
+	_, _ = fmt.Fprintf(w, "Throughput: %s\n", o.format(result.Throughput))
+	_, _ = fmt.Fprintf(w, "Makespan: %d\n", result.Makespan)
+	_, _ = fmt.Fprintf(w, "Average completion: %s\n", o.format(result.AveCompletion))
+	_, switching, idle := CPUUsage(result.Gantt)
+	_, _ = fmt.Fprintf(w, "CPU utilization: %s%%\n", o.format(CPUUtilization(result.Gantt)*100))
+	_, _ = fmt.Fprintf(w, "Idle time: %d\n", idle)
+	if switching > 0 {
+		_, _ = fmt.Fprintf(w, "Switch time: %d\n", switching)
+	}
+	_, _ = fmt.Fprintf(w, "Context switches: %d\n", ContextSwitches(result.Gantt))
+}
+
+// outputFairShares writes a table of the CPU time each process received against its fair share.
+func outputFairShares(w io.Writer, shares []FairShare) {
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Fair share")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Weight", "CPU time", "Fair share"})
+	for _, share := range shares {
+		table.Append([]string{
+			share.ProcessID,
+			fmt.Sprint(share.Weight),
+			fmt.Sprint(share.Actual),
+			fmt.Sprintf("%.2f", share.Fair),
+		})
+	}
+	table.Render()
+}
+
+// outputResult writes the title, gantt chart, and schedule table of a computed schedule.
+func outputResult(w io.Writer, result ScheduleResult) {
+	options{}.output(w, result)
+}
+
+// output writes result like outputResult but drawn as o says, or with outputSummary if o is quiet.
+func (o options) output(w io.Writer, result ScheduleResult) {
+	if o.quiet {
+		outputSummary(w, result)
+		return
+	}
+	outputTitle(w, result.Title)
+	outputGantt(w, result.Gantt, o.gantt...)
+	outputSchedule(w, result, o.columns, o.rowOrder, o.figures...)
+}
+
+// outputSummary writes result's averages on one line of space-separated key=value pairs,
+// such as "avgWait=4.5 avgTurnaround=7.25 throughput=0.4", with each value in full so it parses back exactly.
+func outputSummary(w io.Writer, result ScheduleResult) {
+	value := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	_, _ = fmt.Fprintf(w, "avgWait=%s avgTurnaround=%s throughput=%s\n",
+		value(result.AveWait), value(result.AveTurnaround), value(result.Throughput))
 }
 
 //endregion
 
 //region Loading processes.
 
-var ErrInvalidArgs = errors.New("invalid args")
+var (
+	ErrInvalidArgs = errors.New("invalid args")
+	ErrInvalidCSV  = errors.New("invalid process CSV")
+	ErrInvalidJSON = errors.New("invalid process JSON")
+)
 
-func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
-	}
-	rows = rows[1:] // skip header row
-	processes := make([]Process, len(rows))
-	for i := range rows {
-		processes[i].ProcessID = rows[i][0]
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
+// ParseProcessesCSV reads processes from rows of ProcessID,ArrivalTime,BurstDuration[,Priority],
+// the same layout as example_processes.csv. A header row is optional, and if there is one it names the columns,
+// which may then come in any order: ProcessID, ArrivalTime, BurstDuration, and Priority, matched ignoring case
+// and spaces, so a header of "ProcessID,Burst Duration,Arrival Time" reads bursts from the second column.
+// Empty lines are skipped. Malformed rows and headers return an ErrInvalidCSV error naming the line.
+func ParseProcessesCSV(r io.Reader) ([]Process, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var processes []Process
+	layout := defaultCSVLayout
+	for first := true; ; first = false {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading CSV", err)
+		}
+		line, _ := cr.FieldPos(0)
+		if first && isHeader(row) {
+			if layout, err = parseCSVHeader(row); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCSV, line, err)
+			}
+			continue
+		}
+		p, err := parseProcessRow(row, layout)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCSV, line, err)
 		}
+		processes = append(processes, p)
 	}
 
 	return processes, nil
 }
 
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		log.Fatalln(err)
+// csvLayout is the column of each field in a process CSV, with priority -1 if there is none.
+type csvLayout struct {
+	id, arrival, burst, priority int
+}
+
+// defaultCSVLayout is the layout of a CSV without a header.
+var defaultCSVLayout = csvLayout{id: 0, arrival: 1, burst: 2, priority: 3}
+
+// isHeader reports whether a row's second and third columns are both non-numeric.
+func isHeader(row []string) bool {
+	if len(row) < 3 {
+		return false
+	}
+	_, secondErr := strconv.ParseInt(row[1], 10, 64)
+	_, thirdErr := strconv.ParseInt(row[2], 10, 64)
+	return secondErr != nil && thirdErr != nil
+}
+
+// parseCSVHeader returns the layout a header row names.
+func parseCSVHeader(row []string) (csvLayout, error) {
+	layout := csvLayout{id: -1, arrival: -1, burst: -1, priority: -1}
+	for i, name := range row {
+		var field *int
+		switch strings.ToLower(strings.ReplaceAll(name, " ", "")) {
+		case "processid":
+			field = &layout.id
+		case "arrivaltime":
+			field = &layout.arrival
+		case "burstduration":
+			field = &layout.burst
+		case "priority":
+			field = &layout.priority
+		default:
+			return csvLayout{}, fmt.Errorf("unknown column %q", name)
+		}
+		if *field >= 0 {
+			return csvLayout{}, fmt.Errorf("column %q given twice", name)
+		}
+		*field = i
+	}
+	switch {
+	case layout.id < 0:
+		return csvLayout{}, errors.New("missing ProcessID column")
+	case layout.arrival < 0:
+		return csvLayout{}, errors.New("missing ArrivalTime column")
+	case layout.burst < 0:
+		return csvLayout{}, errors.New("missing BurstDuration column")
+	}
+
+	return layout, nil
+}
+
+func parseProcessRow(row []string, layout csvLayout) (Process, error) {
+	if want := max(layout.id, layout.arrival, layout.burst) + 1; len(row) < want {
+		return Process{}, fmt.Errorf("want at least %d columns, got %d", want, len(row))
+	}
+	p := Process{ProcessID: row[layout.id]}
+	if p.ProcessID == "" {
+		return Process{}, errors.New("missing process ID")
+	}
+	var err error
+	if p.ArrivalTime, err = strconv.ParseInt(row[layout.arrival], 10, 64); err != nil {
+		return Process{}, fmt.Errorf("arrival time %q is not an integer", row[layout.arrival])
+	}
+	if p.ArrivalTime < 0 {
+		return Process{}, fmt.Errorf("negative arrival time %d", p.ArrivalTime)
 	}
-	return i
+	if p.BurstDuration, err = strconv.ParseInt(row[layout.burst], 10, 64); err != nil {
+		return Process{}, fmt.Errorf("burst duration %q is not an integer", row[layout.burst])
+	}
+	if p.BurstDuration < 0 {
+		return Process{}, fmt.Errorf("negative burst duration %d", p.BurstDuration)
+	}
+	if layout.priority >= 0 && layout.priority < len(row) {
+		if p.Priority, err = strconv.ParseInt(row[layout.priority], 10, 64); err != nil {
+			return Process{}, fmt.Errorf("priority %q is not an integer", row[layout.priority])
+		}
+	}
+
+	return p, nil
+}
+
+// jsonProcess is a process as ParseProcessesJSON reads it, with pointers to tell a missing field from a zero.
+type jsonProcess struct {
+	ProcessID     *string `json:"processId"`
+	ArrivalTime   *int64  `json:"arrivalTime"`
+	BurstDuration *int64  `json:"burstDuration"`
+	Priority      int64   `json:"priority"`
+	Deadline      int64   `json:"deadline"`
+}
+
+// ParseProcessesJSON reads processes from a JSON array of objects with processId, arrivalTime, and burstDuration,
+// and optionally priority and deadline. Malformed JSON, a missing or non-integer field, or a negative time
+// returns an ErrInvalidJSON error, naming the position of the process in the array where there is one.
+func ParseProcessesJSON(r io.Reader) ([]Process, error) {
+	var objects []jsonProcess
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+
+	processes := make([]Process, len(objects))
+	for i, object := range objects {
+		switch {
+		case object.ProcessID == nil || *object.ProcessID == "":
+			return nil, fmt.Errorf("%w: process %d: missing processId", ErrInvalidJSON, i)
+		case object.ArrivalTime == nil:
+			return nil, fmt.Errorf("%w: process %d: missing arrivalTime", ErrInvalidJSON, i)
+		case object.BurstDuration == nil:
+			return nil, fmt.Errorf("%w: process %d: missing burstDuration", ErrInvalidJSON, i)
+		case *object.ArrivalTime < 0:
+			return nil, fmt.Errorf("%w: process %d: negative arrival time %d", ErrInvalidJSON, i, *object.ArrivalTime)
+		case *object.BurstDuration < 0:
+			return nil, fmt.Errorf("%w: process %d: negative burst duration %d", ErrInvalidJSON, i, *object.BurstDuration)
+		}
+		processes[i] = Process{
+			ProcessID:     *object.ProcessID,
+			ArrivalTime:   *object.ArrivalTime,
+			BurstDuration: *object.BurstDuration,
+			Priority:      object.Priority,
+			Deadline:      object.Deadline,
+		}
+	}
+
+	return processes, nil
 }
 
 //endregion
@@ -3,12 +3,12 @@ package main
 import (
 	"bytes"
 	"errors"
+	"flag"
 	"io"
 	"os"
 	"path"
 	"strings"
 	"testing"
-	"testing/iotest"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -20,9 +20,10 @@ func TestFCFSSchedule(t *testing.T) {
 		title     string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		wantOut string
+		name       string
+		args       args
+		wantOut    string
+		wantResult ScheduleResult
 	}{
 		{
 			name: "default",
@@ -49,7 +50,26 @@ func TestFCFSSchedule(t *testing.T) {
 				},
 				title: "First-come, first-serve",
 			},
-			wantOut: loadFixture(t, "fcfs_fixture.txt"),
+			wantOut: loadFixture(t, "testdata", "fcfs_fixture.txt"),
+			wantResult: ScheduleResult{
+				Title: "First-come, first-serve",
+				Gantt: []TimeSlice{
+					{PID: "P0", Start: 0, Stop: 5},
+					{PID: "P1", Start: 5, Stop: 14},
+					{PID: "P2", Start: 14, Stop: 20},
+				},
+				Rows: []ScheduleRow{
+					{ProcessID: "P0", Priority: 2, BurstDuration: 5, ArrivalTime: 0, Wait: 0, Turnaround: 5, Completion: 5, Response: 0},
+					{ProcessID: "P1", Priority: 1, BurstDuration: 9, ArrivalTime: 3, Wait: 2, Turnaround: 11, Completion: 14, Response: 2},
+					{ProcessID: "P2", Priority: 3, BurstDuration: 6, ArrivalTime: 6, Wait: 8, Turnaround: 14, Completion: 20, Response: 8},
+				},
+				AveWait:       10.0 / 3,
+				AveTurnaround: 10,
+				AveResponse:   10.0 / 3,
+				Throughput:    3.0 / 20,
+				Makespan:      20,
+				AveCompletion: 13,
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -57,73 +77,737 @@ func TestFCFSSchedule(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			var w bytes.Buffer
-			FCFSSchedule(&w, tt.args.title, tt.args.processes)
+			got := FCFSSchedule(&w, tt.args.title, tt.args.processes)
 			if diff := cmp.Diff(w.String(), tt.wantOut); diff != "" {
 				t.Errorf(diff)
 			}
+			if diff := cmp.Diff(got, tt.wantResult); diff != "" {
+				t.Errorf(diff)
+			}
+			if last := got.Gantt[len(got.Gantt)-1].Stop; got.Makespan != last {
+				t.Errorf("Makespan = %d, want the last gantt stop %d", got.Makespan, last)
+			}
 		})
 	}
 }
 
-func Test_loadProcesses(t *testing.T) {
+func TestTimeSlice_String(t *testing.T) {
 	t.Parallel()
-	type args struct {
-		r io.Reader
+	tests := []struct {
+		name  string
+		slice TimeSlice
+		want  string
+	}{
+		{name: "process", slice: TimeSlice{PID: "P1", Start: 0, Stop: 4}, want: "P1 [0,4)"},
+		{name: "switch", slice: TimeSlice{Start: 4, Stop: 5, Switch: true}, want: "cs [4,5)"},
+		{name: "second CPU", slice: TimeSlice{PID: "P2", Start: 3, Stop: 7, CPU: 1}, want: "P2 [3,7) on CPU 1"},
 	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.slice.String(), tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+
+	got := FormatTimeSlices([]TimeSlice{{PID: "P0", Start: 0, Stop: 2}, {PID: "P1", Start: 2, Stop: 5}})
+	if diff := cmp.Diff(got, "P0 [0,2), P1 [2,5)"); diff != "" {
+		t.Errorf(diff)
+	}
+	if got := FormatTimeSlices(nil); got != "" {
+		t.Errorf("FormatTimeSlices(nil) = %q, want empty", got)
+	}
+}
+
+func Test_outputGantt(t *testing.T) {
+	t.Parallel()
 	tests := []struct {
 		name    string
-		args    args
+		gantt   []TimeSlice
+		opts    []GanttOption
+		wantOut string
+	}{
+		{
+			name: "contiguous",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: "P1", Start: 2, Stop: 3},
+			},
+			wantOut: "Gantt schedule\n" +
+				"|  P0  |  P1  |\n" +
+				"0      2      3\n\n",
+		},
+		{
+			name: "idle gap",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: "P1", Start: 5, Stop: 6},
+			},
+			wantOut: "Gantt schedule\n" +
+				"|  P0    |  idle  |  P1    |\n" +
+				"0        2        5        6\n\n",
+		},
+		{
+			name: "idle before first arrival",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 3, Stop: 4},
+			},
+			wantOut: "Gantt schedule\n" +
+				"|  idle  |  P0    |\n" +
+				"0        3        4\n\n",
+		},
+		{
+			name: "scaled large burst",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 500},
+				{PID: "P1", Start: 500, Stop: 520},
+			},
+			opts: []GanttOption{WithScale(10)},
+			wantOut: "Gantt schedule\n" +
+				"|" + strings.Repeat(" ", 24) + "P0" + strings.Repeat(" ", 24) + "|P1|\n" +
+				"0" + strings.Repeat(" ", 50) + "500" + "\n\n",
+		},
+		{
+			name: "scaled short slice keeps one character",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 100},
+				{PID: "P1", Start: 100, Stop: 101},
+				{PID: "P2", Start: 101, Stop: 201},
+			},
+			opts: []GanttOption{WithScale(25)},
+			wantOut: "Gantt schedule\n" +
+				"| P0 |P|P2 |\n" +
+				"0    100   201\n\n",
+		},
+		{
+			name: "wrapped",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: "P1", Start: 2, Stop: 3},
+				{PID: "P2", Start: 3, Stop: 7},
+				{PID: "P3", Start: 7, Stop: 12},
+				{PID: "P4", Start: 12, Stop: 13},
+			},
+			opts: []GanttOption{WithMaxWidth(20)},
+			wantOut: "Gantt schedule\n" +
+				"|  P0  |  P1  |...\n" +
+				"0      2      3\n" +
+				"|  P2  |  P3  |...\n" +
+				"3      7      12\n" +
+				"|  P4  |\n" +
+				"12     13\n\n",
+		},
+		{
+			name: "last line needs no room for the marker",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: "P1", Start: 2, Stop: 3},
+			},
+			opts: []GanttOption{WithMaxWidth(15)},
+			wantOut: "Gantt schedule\n" +
+				"|  P0  |  P1  |\n" +
+				"0      2      3\n\n",
+		},
+		{
+			name: "boundary ticks",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: "P1", Start: 2, Stop: 3},
+			},
+			opts: []GanttOption{WithTicks(0)},
+			wantOut: "Gantt schedule\n" +
+				"|  P0  |  P1  |\n" +
+				"+------+------+\n" +
+				"0      2      3\n\n",
+		},
+		{
+			name: "interval ticks on a scaled chart",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 40},
+				{PID: "P1", Start: 40, Stop: 45},
+			},
+			opts: []GanttOption{WithScale(2), WithTicks(10)},
+			wantOut: "Gantt schedule\n" +
+				"|         P0         |P1 |\n" +
+				"+----'----'----'-----+---+\n" +
+				"0    10   20   30    40  45\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputGantt(&w, tt.gantt, tt.opts...)
+			if diff := cmp.Diff(w.String(), tt.wantOut); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func Test_outputGantt_multi(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: "P0", Start: 0, Stop: 4},
+		{PID: "P1", Start: 0, Stop: 2, CPU: 1},
+		{PID: "P2", Start: 4, Stop: 6},
+		{PID: "P3", Start: 3, Stop: 6, CPU: 1},
+	}
+	tests := []struct {
+		name string
+		opts []GanttOption
+		want string
+	}{
+		{
+			name: "default",
+			want: `Gantt schedule
+CPU 0 |         P0         |  P2  |
+CPU 1 |  P1  |      |     P3      |
+      0      2      3      4      6
+`,
+		},
+		{
+			name: "scaled",
+			opts: []GanttOption{WithScale(1)},
+			want: `Gantt schedule
+CPU 0 |  P0  |P2|
+CPU 1 |P1| | P3 |
+      0  2 3 4  6
+`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputGantt(&w, gantt, tt.opts...)
+			if diff := cmp.Diff(w.String(), tt.want+"\n"); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+
+	// Every bar on either row sits over the start of a time on the axis.
+	var w bytes.Buffer
+	outputGantt(&w, gantt)
+	lines := strings.Split(w.String(), "\n")
+	axis := lines[3]
+	for _, line := range lines[1:3] {
+		for column, c := range line {
+			if c != '|' {
+				continue
+			}
+			if column >= len(axis) || axis[column] == ' ' || column > 0 && axis[column-1] != ' ' {
+				t.Errorf("bar at column %d is not over a time in:\n%s", column, w.String())
+			}
+		}
+	}
+}
+
+func Test_outputGantt_preempted(t *testing.T) {
+	t.Parallel()
+	// P0's first quantum expires at 2 and it finishes at 4; P1 finishes at 3.
+	result := roundRobin([]Process{
+		{ProcessID: "P0", BurstDuration: 3},
+		{ProcessID: "P1", BurstDuration: 1},
+	}, 2, options{})
+	var w bytes.Buffer
+	outputGantt(&w, result.Gantt)
+	want := "Gantt schedule\n" +
+		"|  P0  |  P1  ]  P0  ]\n" +
+		"0      2      3      4\n\n"
+	if diff := cmp.Diff(w.String(), want); diff != "" {
+		t.Errorf(diff)
+	}
+
+	// Without any preemption every block ends in a plain bar.
+	w.Reset()
+	outputGantt(&w, roundRobin([]Process{{ProcessID: "P0", BurstDuration: 1}}, 2, options{}).Gantt)
+	if strings.Contains(w.String(), completedBar) {
+		t.Errorf("completion marked without preemption:\n%s", w.String())
+	}
+}
+
+func Test_outputGantt_legend(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: "Alpha", Start: 0, Stop: 2},
+		{PID: "Beta", Start: 2, Stop: 10},
+		{PID: "Alpha", Start: 10, Stop: 13},
+		{PID: "Gamma", Start: 15, Stop: 16},
+	}
+	multi := append([]TimeSlice{}, gantt...)
+	multi[1].CPU = 1
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		opts  []GanttOption
+		want  string
+	}{
+		{
+			name: "whole labels",
+			opts: []GanttOption{WithLegend()},
+			want: "Legend: Alpha, Beta, Gamma\n",
+		},
+		{
+			name: "cut labels",
+			opts: []GanttOption{WithLegend(), WithScale(1)},
+			want: "Legend: Al = Alpha, Beta, G = Gamma\n",
+		},
+		{
+			name: "coloured",
+			opts: []GanttOption{WithLegend(), WithColor(ColorAlways)},
+			want: "Legend: " + blockColor(gantt[0]) + "Alpha" + ansiReset + ", " +
+				blockColor(gantt[1]) + "Beta" + ansiReset + ", " + blockColor(gantt[3]) + "Gamma" + ansiReset + "\n",
+		},
+		{
+			name:  "several CPUs",
+			gantt: multi,
+			opts:  []GanttOption{WithLegend(), WithScale(1)},
+			want:  "Legend: Al = Alpha, G = Gamma, Beta\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			chart := tt.gantt
+			if chart == nil {
+				chart = gantt
+			}
+			var w bytes.Buffer
+			outputGantt(&w, chart, tt.opts...)
+			lines := strings.Split(strings.TrimSuffix(w.String(), "\n\n"), "\n")
+			legend := lines[len(lines)-1] + "\n"
+			if diff := cmp.Diff(legend, tt.want); diff != "" {
+				t.Errorf("%s\n%s", diff, w.String())
+			}
+			for _, pid := range []string{"Alpha", "Beta", "Gamma"} {
+				if n := strings.Count(legend, pid); n != 1 {
+					t.Errorf("%s is in the legend %d times: %q", pid, n, legend)
+				}
+			}
+		})
+	}
+
+	// Without the option there is no legend.
+	var w bytes.Buffer
+	outputGantt(&w, gantt)
+	if strings.Contains(w.String(), "Legend") {
+		t.Errorf("legend written without WithLegend:\n%s", w.String())
+	}
+
+	// A process may be called idle, and is named like any other, though idle time is not.
+	w.Reset()
+	outputGantt(&w, []TimeSlice{{PID: "idle", Start: 0, Stop: 2}, {PID: "P1", Start: 3, Stop: 4}}, WithLegend())
+	if !strings.Contains(w.String(), "Legend: idle, P1\n") {
+		t.Errorf("process named idle left out of the legend:\n%s", w.String())
+	}
+}
+
+func Test_outputGantt_color(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: "P0", Start: 0, Stop: 2},
+		{PID: "P1", Start: 3, Stop: 4},
+	}
+	plain := "Gantt schedule\n" +
+		"|  P0    |  idle  |  P1    |\n" +
+		"0        2        3        4\n\n"
+	tests := []struct {
+		name    string
+		opts    []GanttOption
+		wantOut string
+	}{
+		{
+			name:    "default",
+			wantOut: plain,
+		},
+		{
+			name: "always",
+			opts: []GanttOption{WithColor(ColorAlways)},
+			wantOut: "Gantt schedule\n" +
+				"|\x1b[34m  P0    \x1b[0m|\x1b[2;37m  idle  \x1b[0m|\x1b[35m  P1    \x1b[0m|\n" +
+				"0        2        3        4\n\n",
+		},
+		{
+			name:    "auto on a buffer",
+			opts:    []GanttOption{WithColor(ColorAuto)},
+			wantOut: plain,
+		},
+		{
+			name:    "no color wins",
+			opts:    []GanttOption{NoColor(), WithColor(ColorAlways)},
+			wantOut: plain,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputGantt(&w, gantt, tt.opts...)
+			if diff := cmp.Diff(w.String(), tt.wantOut); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func Test_blockColor(t *testing.T) {
+	t.Parallel()
+	if blockColor(TimeSlice{PID: "P7"}) != blockColor(TimeSlice{PID: "P7", Start: 40, Stop: 41}) {
+		t.Errorf("the same PID got different colours")
+	}
+	if got := blockColor(TimeSlice{Switch: true}); got != "" {
+		t.Errorf("blockColor(switch) = %q, want none", got)
+	}
+	if blockColor(TimeSlice{PID: idleLabel}) == ansiIdle {
+		t.Errorf("a process named %q is coloured as idle time", idleLabel)
+	}
+}
+
+func Test_outputGantt_tickColumn(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputGantt(&w, []TimeSlice{
+		{PID: "P0", Start: 0, Stop: 30},
+		{PID: "P1", Start: 30, Stop: 90},
+	}, WithScale(3), WithTicks(0))
+	lines := strings.Split(w.String(), "\n")
+	// P0 takes 10 characters after the opening bar, so its closing bar is at column 11.
+	if got := strings.Index(lines[1][1:], "|") + 1; got != 11 {
+		t.Errorf("bar at column %d, want 11", got)
+	}
+	if got := strings.Index(lines[2][1:], "+") + 1; got != 11 {
+		t.Errorf("tick at column %d, want 11", got)
+	}
+	if got := strings.Index(lines[3], "30"); got != 11 {
+		t.Errorf("time 30 at column %d, want 11", got)
+	}
+}
+
+func Test_options_output(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P1", ArrivalTime: 10, BurstDuration: 3},
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 30},
+	}
+	tests := []struct {
+		name    string
+		opts    []Option
+		gantt   []GanttOption
+		columns ColumnSet
+		order   RowOrder
+		figures []ScheduleOption
+	}{
+		{name: "none"},
+		{
+			name:  "scale",
+			opts:  []Option{WithGanttOptions(WithScale(3))},
+			gantt: []GanttOption{WithScale(3)},
+		},
+		{
+			name:  "color",
+			opts:  []Option{WithGanttOptions(WithColor(ColorAlways))},
+			gantt: []GanttOption{WithColor(ColorAlways)},
+		},
+		{
+			name:  "max width",
+			opts:  []Option{WithGanttOptions(WithMaxWidth(10))},
+			gantt: []GanttOption{WithMaxWidth(10)},
+		},
+		{
+			name:  "ticks",
+			opts:  []Option{WithGanttOptions(WithScale(3), WithTicks(5))},
+			gantt: []GanttOption{WithScale(3), WithTicks(5)},
+		},
+		{
+			name:  "legend",
+			opts:  []Option{WithGanttOptions(WithLegend())},
+			gantt: []GanttOption{WithLegend()},
+		},
+		{
+			name:  "no color",
+			opts:  []Option{WithGanttOptions(WithColor(ColorAlways)), WithGanttOptions(NoColor())},
+			gantt: []GanttOption{NoColor()},
+		},
+		{
+			name:    "columns",
+			opts:    []Option{WithColumns(ColumnSet{ColumnID, ColumnResponse, ColumnResponseRatio})},
+			columns: ColumnSet{ColumnID, ColumnResponse, ColumnResponseRatio},
+		},
+		{
+			name:  "row order",
+			opts:  []Option{WithRowOrder(RowsByCompletion)},
+			order: RowsByCompletion,
+		},
+		{
+			name:    "figures",
+			opts:    []Option{WithScheduleOptions(WithDecimals(3), WithTrimZeros())},
+			figures: []ScheduleOption{WithDecimals(3), WithTrimZeros()},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			result := FCFSSchedule(&w, "FCFS", processes, tt.opts...)
+
+			var want bytes.Buffer
+			outputTitle(&want, "FCFS")
+			outputGantt(&want, result.Gantt, tt.gantt...)
+			outputSchedule(&want, result, tt.columns, tt.order, tt.figures...)
+			if diff := cmp.Diff(w.String(), want.String()); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+
+	// The CLI passes them on too.
+	var w bytes.Buffer
+	run(&w, SJFPriority{}, processes, WithGanttOptions(WithScale(3)))
+	if !strings.Contains(w.String(), "|    P0    |P|\n") {
+		t.Errorf("run() did not scale the chart:\n%s", w.String())
+	}
+}
+
+func TestSortRows(t *testing.T) {
+	t.Parallel()
+	rows := []ScheduleRow{
+		{ProcessID: "P2", ArrivalTime: 0, Completion: 9},
+		{ProcessID: "P0", ArrivalTime: 4, Completion: 5},
+		{ProcessID: "P1", ArrivalTime: 2, Completion: 12},
+		{ProcessID: "P3", ArrivalTime: 2, Completion: 3},
+	}
+	tests := []struct {
+		name  string
+		order RowOrder
+		want  []string
+	}{
+		{name: "input", order: RowsByInput, want: []string{"P2", "P0", "P1", "P3"}},
+		{name: "ID", order: RowsByID, want: []string{"P0", "P1", "P2", "P3"}},
+		{name: "completion", order: RowsByCompletion, want: []string{"P3", "P0", "P2", "P1"}},
+		{name: "arrival ties in input order", order: RowsByArrival, want: []string{"P2", "P1", "P3", "P0"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, row := range SortRows(rows, tt.order) {
+				got = append(got, row.ProcessID)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+	if rows[0].ProcessID != "P2" {
+		t.Errorf("SortRows reordered its input")
+	}
+}
+
+func Test_outputSchedule(t *testing.T) {
+	t.Parallel()
+	result := ScheduleResult{
+		Rows: []ScheduleRow{
+			{ProcessID: "A", BurstDuration: 6, Turnaround: 6, Completion: 6},
+			{ProcessID: "B", BurstDuration: 5, ArrivalTime: 1, Wait: 5, Turnaround: 10, Completion: 11, Response: 5},
+		},
+	}
+	tests := []struct {
+		name      string
+		columns   ColumnSet
+		order     RowOrder
+		wantTable string
+	}{
+		{
+			name: "default",
+			wantTable: `+----+----------+-------+---------+------+------------+------+
+| ID | PRIORITY | BURST | ARRIVAL | WAIT | TURNAROUND | EXIT |
++----+----------+-------+---------+------+------------+------+
+| A  |        0 |     6 |       0 |    0 |          6 |    6 |
+| B  |        0 |     5 |       1 |    5 |         10 |   11 |
++----+----------+-------+---------+------+------------+------+
+`,
+		},
+		{
+			name:    "custom subset",
+			columns: ColumnSet{ColumnID, ColumnResponse, ColumnResponseRatio},
+			wantTable: `+----+----------+----------------+
+| ID | RESPONSE | RESPONSE RATIO |
++----+----------+----------------+
+| A  |        0 |           1.00 |
+| B  |        5 |           2.00 |
++----+----------+----------------+
+`,
+		},
+		{
+			name:    "sorted by completion",
+			columns: ColumnSet{ColumnID, ColumnExit},
+			order:   RowsByCompletion,
+			wantTable: `+----+------+
+| ID | EXIT |
++----+------+
+| A  |    6 |
+| B  |   11 |
++----+------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputSchedule(&w, result, tt.columns, tt.order)
+			got := strings.TrimPrefix(w.String(), "Schedule table\n")
+			got = got[:strings.Index(got, "\n\n")+1]
+			if diff := cmp.Diff(got, tt.wantTable); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func Test_outputSchedule_format(t *testing.T) {
+	t.Parallel()
+	result := ScheduleResult{
+		Rows: []ScheduleRow{
+			{ProcessID: "A", BurstDuration: 3, Turnaround: 3, Completion: 3},
+			{ProcessID: "B", BurstDuration: 4, Wait: 3, Turnaround: 7, Completion: 7, Response: 3},
+			{ProcessID: "C", BurstDuration: 2, Wait: 6, Turnaround: 8, Completion: 9, ArrivalTime: 1, Response: 6},
+		},
+		AveWait:       3,
+		AveTurnaround: 6,
+		Throughput:    1.0 / 3,
+	}
+	tests := []struct {
+		name string
+		opts []ScheduleOption
+		want []string
+	}{
+		{
+			name: "default",
+			want: []string{"Average wait: 3.00\n", "Average turnaround: 6.00\n", "Throughput: 0.33\n"},
+		},
+		{
+			name: "3 decimals",
+			opts: []ScheduleOption{WithDecimals(3)},
+			want: []string{"Average wait: 3.000\n", "Throughput: 0.333\n"},
+		},
+		{
+			name: "trailing zeros",
+			opts: []ScheduleOption{WithTrimZeros()},
+			want: []string{"Average wait: 3\n", "Average turnaround: 6\n", "Throughput: 0.33\n", "CPU utilization: 0%\n"},
+		},
+		{
+			name: "3 decimals without trailing zeros",
+			opts: []ScheduleOption{WithDecimals(3), WithTrimZeros()},
+			want: []string{"Average wait: 3\n", "Throughput: 0.333\n", "Wait variance: 6\n"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputSchedule(&w, result, nil, RowsByInput, tt.opts...)
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("missing %q in:\n%s", want, w.String())
+				}
+			}
+		})
+	}
+}
+
+func TestParseProcessesCSV(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		in      string
 		want    []Process
-		wantErr error
+		wantErr string
 	}{
 		{
-			name: "bad CSV",
-			args: args{
-				r: iotest.ErrReader(io.ErrUnexpectedEOF),
+			name: "well formed",
+			in:   "P0,0,5,2\nP1,3,9,1\n",
+			want: []Process{
+				{ProcessID: "P0", BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+				{ProcessID: "P1", BurstDuration: 9, ArrivalTime: 3, Priority: 1},
 			},
-			wantErr: io.ErrUnexpectedEOF,
 		},
 		{
-			name: "success",
-			args: args{
-				r: strings.NewReader(`ProcessID,Burst Duration,Arrival Time,Priority
-P0,5,0,2
-P1,9,3,1
-P2,6,3,3`),
+			name: "header and empty lines",
+			in:   "ProcessID,ArrivalTime,BurstDuration,Priority\n\nP0,0,5,2\n\nP1,3,9\n",
+			want: []Process{
+				{ProcessID: "P0", BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+				{ProcessID: "P1", BurstDuration: 9, ArrivalTime: 3},
 			},
+		},
+		{
+			name: "header in another order",
+			in:   "ProcessID,Burst Duration,Arrival Time,Priority\nP0,5,0,2\nP1,9,3,1\n",
 			want: []Process{
-				{
-					ProcessID:     "P0",
-					ArrivalTime:   0,
-					BurstDuration: 5,
-					Priority:      2,
-				},
-				{
-					ProcessID:     "P1",
-					ArrivalTime:   3,
-					BurstDuration: 9,
-					Priority:      1,
-				},
-				{
-					ProcessID:     "P2",
-					ArrivalTime:   3,
-					BurstDuration: 6,
-					Priority:      3,
-				},
+				{ProcessID: "P0", BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+				{ProcessID: "P1", BurstDuration: 9, ArrivalTime: 3, Priority: 1},
 			},
 		},
+		{
+			name:    "unknown header column",
+			in:      "ProcessID,Arrival,Burst\nP0,0,5\n",
+			wantErr: `invalid process CSV: line 1: unknown column "Arrival"`,
+		},
+		{
+			name:    "header missing a column",
+			in:      "ProcessID,Priority,ArrivalTime\nP0,1,0\n",
+			wantErr: "invalid process CSV: line 1: missing BurstDuration column",
+		},
+		{
+			name:    "non-integer burst",
+			in:      "P0,0,5,2\nP1,3,x,1\n",
+			wantErr: `invalid process CSV: line 2: burst duration "x" is not an integer`,
+		},
+		{
+			name:    "negative arrival",
+			in:      "ProcessID,ArrivalTime,BurstDuration,Priority\nP0,-1,5,2\n",
+			wantErr: "invalid process CSV: line 2: negative arrival time -1",
+		},
+		{
+			name:    "missing column",
+			in:      "P0,0,5\n\nP1,3\n",
+			wantErr: "invalid process CSV: line 3: want at least 3 columns, got 2",
+		},
+		{
+			name:    "non-integer priority",
+			in:      "P0,0,5,high\n",
+			wantErr: `invalid process CSV: line 1: priority "high" is not an integer`,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := loadProcesses(tt.args.r)
+			got, err := ParseProcessesCSV(strings.NewReader(tt.in))
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrInvalidCSV) || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 			if diff := cmp.Diff(got, tt.want); diff != "" {
 				t.Errorf(diff)
 			}
-			if !errors.Is(err, tt.wantErr) {
-				t.Errorf("error = %v, want %v", err, tt.wantErr)
-			}
 		})
 	}
 }
@@ -137,72 +821,354 @@ func loadFixture(t *testing.T, p ...string) string {
 	return string(b)
 }
 
-func Test_openProcessingFile1(t *testing.T) {
-	tmpFile, tErr := os.CreateTemp(t.TempDir(), "")
-	if tErr != nil {
-		t.Fatal(tErr)
-	}
-
-	type args struct {
-		args []string
-	}
+func TestParseProcessesJSON(t *testing.T) {
+	t.Parallel()
 	tests := []struct {
 		name    string
-		args    args
-		want    *os.File
-		wantErr bool
+		in      string
+		want    []Process
+		wantErr string
 	}{
 		{
-			name: "success",
-			args: args{
-				args: []string{"binary_name", tmpFile.Name()},
+			name: "valid array",
+			in: `[
+				{"processId": "P0", "arrivalTime": 0, "burstDuration": 5, "priority": 2},
+				{"processId": "P1", "arrivalTime": 3, "burstDuration": 9, "priority": 1, "deadline": 20}
+			]`,
+			want: []Process{
+				{ProcessID: "P0", BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+				{ProcessID: "P1", BurstDuration: 9, ArrivalTime: 3, Priority: 1, Deadline: 20},
 			},
-			want: tmpFile,
 		},
 		{
-			name: "not enough args",
-			args: args{
-				args: []string{"binary_name"},
-			},
-			wantErr: true,
+			name: "empty array",
+			in:   "[]",
+			want: []Process{},
 		},
 		{
-			name: "bad file",
-			args: args{
-				args: []string{"binary_name", "bad_file_name"},
-			},
-			wantErr: true,
+			name:    "malformed object",
+			in:      `[{"processId": "P0", "arrivalTime": 0, "burstDuration": 5,}]`,
+			wantErr: "invalid process JSON: invalid character '}' looking for beginning of object key string",
+		},
+		{
+			name:    "non-numeric field",
+			in:      `[{"processId": "P0", "arrivalTime": 0, "burstDuration": "five"}]`,
+			wantErr: "invalid process JSON: json: cannot unmarshal string into Go struct field",
+		},
+		{
+			name:    "missing field",
+			in:      `[{"processId": "P0", "arrivalTime": 0, "burstDuration": 5}, {"processId": "P1", "arrivalTime": 2}]`,
+			wantErr: "invalid process JSON: process 1: missing burstDuration",
+		},
+		{
+			name:    "negative burst",
+			in:      `[{"processId": "P0", "arrivalTime": 0, "burstDuration": -5}]`,
+			wantErr: "invalid process JSON: process 0: negative burst duration -5",
 		},
 	}
 	for _, tt := range tests {
+		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			got, closeFn, err := openProcessingFile(tt.args.args...)
-			if (err != nil) != tt.wantErr {
-				t.Fatalf("openProcessingFile() error = %v, wantErr %v", err, tt.wantErr)
+			t.Parallel()
+			got, err := ParseProcessesJSON(strings.NewReader(tt.in))
+			if tt.wantErr != "" {
+				// encoding/json words its messages differently across Go versions, so only the start is compared.
+				if !errors.Is(err, ErrInvalidJSON) || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want prefix %v", err, tt.wantErr)
+				}
+				return
 			}
 			if err != nil {
-				return
+				t.Fatalf("unexpected error: %v", err)
 			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
 
-			if got == nil {
-				t.Fatal("file is unexpectedly nil")
+func FuzzParseProcessesCSV(f *testing.F) {
+	example, err := os.ReadFile("example_processes.csv")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+	f.Add([]byte(""))
+	f.Add([]byte("P0,5\n"))
+	f.Add([]byte("P0,5,0,\"2\n"))
+	f.Add([]byte("ProcessID,BurstDuration,ArrivalTime\n,1,2\n\nP1,-1,0\n"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		processes, err := ParseProcessesCSV(bytes.NewReader(data))
+		if err != nil {
+			if processes != nil {
+				t.Errorf("got processes %v along with error %v", processes, err)
 			}
-			if closeFn == nil {
-				t.Fatal("closeFn is unexpectedly nil")
+			return
+		}
+		for _, p := range processes {
+			if p.ProcessID == "" || p.BurstDuration < 0 || p.ArrivalTime < 0 {
+				t.Errorf("parsed an invalid process %+v", p)
 			}
-			t.Cleanup(closeFn)
+		}
+	})
+}
 
-			f1, err := os.Stat(got.Name())
-			if err != nil {
-				t.Fatalf("Could not stat file: %v", got)
+func Test_run(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: "P2", ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		algorithm string
+		params    map[string]string
+		wantTitle string
+		wantErr   error
+	}{
+		{algorithm: "fcfs", wantTitle: "First-come, first-serve"},
+		{algorithm: "sjf", wantTitle: "Shortest-job-first"},
+		{algorithm: "sjfp", wantTitle: "Shortest-job-first priority"},
+		{algorithm: "srtf", wantTitle: "Shortest-remaining-time-first"},
+		{algorithm: "priority", wantTitle: "Priority"},
+		{algorithm: "priority", params: map[string]string{"preemptive": "true"}, wantTitle: "Preemptive priority"},
+		{algorithm: "rr", params: map[string]string{"quantum": "2"}, wantTitle: "Round-robin"},
+		{algorithm: "lottery", wantErr: ErrUnknownScheduler},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.algorithm, func(t *testing.T) {
+			t.Parallel()
+			scheduler, err := NewScheduler(tt.algorithm, tt.params)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
 			}
-			f2, err := os.Stat(tt.want.Name())
 			if err != nil {
-				t.Fatalf("Could not stat file: %v", tt.want)
+				for _, name := range []string{"fcfs", "sjf", "sjfp", "srtf", "priority", "rr"} {
+					if !strings.Contains(err.Error(), name) {
+						t.Errorf("error %q does not list %s", err, name)
+					}
+				}
+				return
 			}
 
-			if !os.SameFile(f1, f2) {
-				t.Fatal("files are not the same")
+			var w bytes.Buffer
+			got := run(&w, scheduler, processes)
+			if got.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", got.Title, tt.wantTitle)
+			}
+			if !strings.Contains(w.String(), tt.wantTitle) {
+				t.Errorf("output missing title %q:\n%s", tt.wantTitle, w.String())
+			}
+		})
+	}
+
+	// Invalid input is explained rather than scheduled.
+	var w bytes.Buffer
+	if got := run(&w, FCFS{}, []Process{{ProcessID: "P0"}}); len(got.Rows) != 0 {
+		t.Errorf("run() scheduled invalid input: %+v", got)
+	}
+	if !strings.Contains(w.String(), ErrInvalidProcess.Error()) {
+		t.Errorf("run() did not explain invalid input: %q", w.String())
+	}
+}
+
+func Test_parseCLI(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		args        []string
+		wantCmd     Scheduler
+		wantGantt   ganttOptions
+		wantColumns ColumnSet
+		wantOrder   RowOrder
+		wantFigures scheduleOptions
+		wantErr     error
+	}{
+		{
+			name:        "input flag",
+			args:        []string{"-algorithm", "rr", "-quantum", "3", "-input", "example_processes.csv"},
+			wantCmd:     RR{Quantum: 3},
+			wantGantt:   ganttOptions{colorMode: ColorAuto},
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:        "input argument",
+			args:        []string{"-algorithm", "sjf", "example_processes.csv"},
+			wantCmd:     SJF{},
+			wantGantt:   ganttOptions{colorMode: ColorAuto},
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:        "scale",
+			args:        []string{"-algorithm", "fcfs", "-scale", "2", "example_processes.csv"},
+			wantCmd:     FCFS{},
+			wantGantt:   ganttOptions{scale: 2, colorMode: ColorAuto},
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:        "color",
+			args:        []string{"-algorithm", "fcfs", "-color", "never", "example_processes.csv"},
+			wantCmd:     FCFS{},
+			wantGantt:   ganttOptions{colorMode: ColorNever},
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:    "bad color",
+			args:    []string{"-algorithm", "fcfs", "-color", "sometimes", "example_processes.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:        "width",
+			args:        []string{"-algorithm", "fcfs", "-width", "40", "example_processes.csv"},
+			wantCmd:     FCFS{},
+			wantGantt:   ganttOptions{maxWidth: 40, colorMode: ColorAuto},
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:    "negative width",
+			args:    []string{"-algorithm", "fcfs", "-width", "-1", "example_processes.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:        "ticks",
+			args:        []string{"-algorithm", "fcfs", "-ticks", "5", "example_processes.csv"},
+			wantCmd:     FCFS{},
+			wantGantt:   ganttOptions{ticks: true, tickEvery: 5, colorMode: ColorAuto},
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:        "boundary ticks",
+			args:        []string{"-algorithm", "fcfs", "-ticks", "0", "example_processes.csv"},
+			wantCmd:     FCFS{},
+			wantGantt:   ganttOptions{ticks: true, colorMode: ColorAuto},
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:    "bad ticks",
+			args:    []string{"-algorithm", "fcfs", "-ticks", "-2", "example_processes.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:        "legend",
+			args:        []string{"-algorithm", "fcfs", "-legend", "example_processes.csv"},
+			wantCmd:     FCFS{},
+			wantGantt:   ganttOptions{legend: true, colorMode: ColorAuto},
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:        "columns",
+			args:        []string{"-algorithm", "fcfs", "-columns", "id, wait,response-ratio", "example_processes.csv"},
+			wantCmd:     FCFS{},
+			wantGantt:   ganttOptions{colorMode: ColorAuto},
+			wantColumns: ColumnSet{ColumnID, ColumnWait, ColumnResponseRatio},
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:    "unknown column",
+			args:    []string{"-algorithm", "fcfs", "-columns", "id,slack", "example_processes.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:        "sort",
+			args:        []string{"-algorithm", "fcfs", "-sort", "completion", "example_processes.csv"},
+			wantCmd:     FCFS{},
+			wantGantt:   ganttOptions{colorMode: ColorAuto},
+			wantOrder:   RowsByCompletion,
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:    "bad sort",
+			args:    []string{"-algorithm", "fcfs", "-sort", "burst", "example_processes.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:        "figures",
+			args:        []string{"-algorithm", "fcfs", "-decimals", "4", "-trim", "example_processes.csv"},
+			wantCmd:     FCFS{},
+			wantGantt:   ganttOptions{colorMode: ColorAuto},
+			wantFigures: scheduleOptions{decimals: 4, trimZeros: true},
+		},
+		{
+			name:    "negative decimals",
+			args:    []string{"-algorithm", "fcfs", "-decimals", "-1", "example_processes.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "negative scale",
+			args:    []string{"-algorithm", "fcfs", "-scale", "-1", "example_processes.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:        "preemptive",
+			args:        []string{"-algorithm", "priority", "-preemptive", "example_processes.csv"},
+			wantCmd:     Priority{Preemptive: true},
+			wantGantt:   ganttOptions{colorMode: ColorAuto},
+			wantFigures: scheduleOptions{decimals: 2},
+		},
+		{
+			name:    "missing algorithm",
+			args:    []string{"-input", "example_processes.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "unknown algorithm",
+			args:    []string{"-algorithm", "nope", "-input", "example_processes.csv"},
+			wantErr: ErrUnknownScheduler,
+		},
+		{
+			name:    "bad quantum",
+			args:    []string{"-algorithm", "rr", "-quantum", "0", "-input", "example_processes.csv"},
+			wantErr: ErrSchedulerParams,
+		},
+		{
+			name:    "missing file",
+			args:    []string{"-algorithm", "rr", "-input", "bad_file_name"},
+			wantErr: os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+			cmd, data, opts, err := parseCLI(flagSet, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if c, ok := data.(io.Closer); ok {
+				t.Cleanup(func() { _ = c.Close() })
+			}
+			if cmd != tt.wantCmd {
+				t.Errorf("parseCLI() = %#v, want %#v", cmd, tt.wantCmd)
+			}
+			var gantt ganttOptions
+			for _, opt := range newOptions(opts).gantt {
+				opt(&gantt)
+			}
+			if gantt != tt.wantGantt {
+				t.Errorf("parseCLI() gantt options = %+v, want %+v", gantt, tt.wantGantt)
+			}
+			if diff := cmp.Diff(newOptions(opts).columns, tt.wantColumns); diff != "" {
+				t.Errorf(diff)
+			}
+			if order := newOptions(opts).rowOrder; order != tt.wantOrder {
+				t.Errorf("parseCLI() row order = %v, want %v", order, tt.wantOrder)
+			}
+			var figures scheduleOptions
+			for _, opt := range newOptions(opts).figures {
+				opt(&figures)
+			}
+			if figures != tt.wantFigures {
+				t.Errorf("parseCLI() schedule options = %+v, want %+v", figures, tt.wantFigures)
+			}
+			processes, err := ParseProcessesCSV(data)
+			if err != nil || len(processes) != 5 {
+				t.Errorf("ParseProcessesCSV() = %d processes, %v", len(processes), err)
 			}
 		})
 	}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+//region Metrics
+
+// ComputeAverages returns the average wait and turnaround of rows,
+// and the throughput of completing them all by lastCompletion. Empty rows average to 0.
+func ComputeAverages(rows []ScheduleRow, lastCompletion float64) (aveWait, aveTurnaround, aveThroughput float64) {
+	if len(rows) == 0 {
+		return 0, 0, 0
+	}
+	// Sum as floats, where many long waits cannot overflow.
+	var totalWait, totalTurnaround float64
+	for _, row := range rows {
+		totalWait += float64(row.Wait)
+		totalTurnaround += float64(row.Turnaround)
+	}
+	count := float64(len(rows))
+	if lastCompletion > 0 {
+		aveThroughput = count / lastCompletion
+	}
+
+	return totalWait / count, totalTurnaround / count, aveThroughput
+}
+
+// newScheduleResult fills in the averages and makespan of a schedule from its rows, with throughput measured up to the last completion.
+func newScheduleResult(gantt []TimeSlice, rows []ScheduleRow) ScheduleResult {
+	var (
+		lastCompletion                 int64
+		totalResponse, totalCompletion float64
+	)
+	for _, row := range rows {
+		if row.Completion > lastCompletion {
+			lastCompletion = row.Completion
+		}
+		totalResponse += float64(row.Response)
+		totalCompletion += float64(row.Completion)
+	}
+	result := ScheduleResult{Gantt: gantt, Rows: rows, Makespan: lastCompletion}
+	result.AveWait, result.AveTurnaround, result.Throughput = ComputeAverages(rows, float64(lastCompletion))
+	if len(rows) > 0 {
+		result.AveResponse = totalResponse / float64(len(rows))
+		result.AveCompletion = totalCompletion / float64(len(rows))
+	}
+
+	return result
+}
+
+// WaitSpread returns the population variance and standard deviation of the waits in rows.
+// A low average wait can hide a few badly starved processes; the spread shows them. Empty rows spread to 0.
+func WaitSpread(rows []ScheduleRow) (variance, stdDev float64) {
+	return spread(rows, func(row ScheduleRow) int64 { return row.Wait })
+}
+
+// TurnaroundSpread is WaitSpread for turnaround times.
+func TurnaroundSpread(rows []ScheduleRow) (variance, stdDev float64) {
+	return spread(rows, func(row ScheduleRow) int64 { return row.Turnaround })
+}
+
+func spread(rows []ScheduleRow, value func(ScheduleRow) int64) (variance, stdDev float64) {
+	if len(rows) == 0 {
+		return 0, 0
+	}
+	count := float64(len(rows))
+	var mean float64
+	for _, row := range rows {
+		mean += float64(value(row))
+	}
+	mean /= count
+	for _, row := range rows {
+		d := float64(value(row)) - mean
+		variance += d * d
+	}
+	variance /= count
+
+	return variance, math.Sqrt(variance)
+}
+
+// Percentiles are the times that 50, 90, 95, and 99 percent of processes came in at or under.
+type Percentiles struct {
+	P50, P90, P95, P99 int64
+}
+
+// WaitPercentiles returns the percentiles of the waits in rows by nearest rank: the pth percentile is the smallest wait
+// at least p percent of the rows are at or under, so it is always one of the waits. Empty rows give all 0.
+func WaitPercentiles(rows []ScheduleRow) Percentiles {
+	return percentiles(rows, func(row ScheduleRow) int64 { return row.Wait })
+}
+
+// TurnaroundPercentiles is WaitPercentiles for turnaround times.
+func TurnaroundPercentiles(rows []ScheduleRow) Percentiles {
+	return percentiles(rows, func(row ScheduleRow) int64 { return row.Turnaround })
+}
+
+func percentiles(rows []ScheduleRow, value func(ScheduleRow) int64) Percentiles {
+	if len(rows) == 0 {
+		return Percentiles{}
+	}
+	values := make([]int64, len(rows))
+	for i, row := range rows {
+		values[i] = value(row)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	rank := func(p int) int64 {
+		// The ceiling of p% of the count, as a 1-based rank.
+		return values[(p*len(values)+99)/100-1]
+	}
+
+	return Percentiles{P50: rank(50), P90: rank(90), P95: rank(95), P99: rank(99)}
+}
+
+// CPUUsage returns the busy, switching and idle time of a gantt chart, where the CPU is taken to start at
+// time 0 and run until the last slice stops. Switch-cost slices count as switching rather than busy, and
+// gaps between slices count as idle.
+// For a chart across several CPUs, the times are summed over every CPU up to the last stop on any of them.
+func CPUUsage(gantt []TimeSlice) (busy, switching, idle int64) {
+	var end int64
+	cpus := cpuCount(gantt)
+	for _, slice := range gantt {
+		if slice.Switch {
+			switching += slice.Stop - slice.Start
+		} else {
+			busy += slice.Stop - slice.Start
+		}
+		if slice.Stop > end {
+			end = slice.Stop
+		}
+	}
+
+	return busy, switching, end*int64(cpus) - busy - switching
+}
+
+// cpuCount is the number of CPUs a gantt chart spans, at least 1.
+func cpuCount(gantt []TimeSlice) int {
+	cpus := 1
+	for _, slice := range gantt {
+		if slice.CPU+1 > cpus {
+			cpus = slice.CPU + 1
+		}
+	}
+
+	return cpus
+}
+
+// CPUUtilization is the fraction of time from 0 to the last slice stop that the CPU was busy.
+// Time spent on context switches is not counted as busy.
+func CPUUtilization(gantt []TimeSlice) float64 {
+	busy, switching, idle := CPUUsage(gantt)
+	total := busy + switching + idle
+	if total == 0 {
+		return 0
+	}
+
+	return float64(busy) / float64(total)
+}
+
+// ContextSwitches counts the times a CPU in gantt moves from running one process to running a different one.
+// Idle time and switch-cost slices are skipped over rather than counted, so P0, idle, P1 is one switch and
+// P0, idle, P0 is none. The first process on each CPU is not a switch.
+func ContextSwitches(gantt []TimeSlice) int {
+	var switches int
+	last := make(map[int]string)
+	for _, slice := range gantt {
+		if slice.Switch {
+			continue
+		}
+		if prev, ok := last[slice.CPU]; ok && prev != slice.PID {
+			switches++
+		}
+		last[slice.CPU] = slice.PID
+	}
+
+	return switches
+}
+
+// Utilization splits the makespan of a schedule by what one process was doing, as fractions that sum to 1.
+type Utilization struct {
+	// Running is the time the process was on a CPU.
+	Running float64 `json:"running"`
+	// Waiting is the time it was ready but not running.
+	Waiting float64 `json:"waiting"`
+	// Blocked is the time it was neither running nor ready, such as doing I/O under FCFSIOSchedule.
+	Blocked float64 `json:"blocked"`
+	// Absent is the time before it arrived or after it completed.
+	Absent float64 `json:"absent"`
+}
+
+// ProcessUtilization returns how each process in result spent the makespan, keyed by ProcessID.
+// Running time comes from the gantt chart, and waiting and blocked time from the rows,
+// so only schedulers that do I/O such as FCFSIOSchedule give any blocked time.
+// A schedule with no makespan gives an empty map.
+func ProcessUtilization(result ScheduleResult) map[string]Utilization {
+	utilizations := make(map[string]Utilization, len(result.Rows))
+	if result.Makespan <= 0 {
+		return utilizations
+	}
+	running := make(map[string]int64, len(result.Rows))
+	for _, slice := range result.Gantt {
+		if !slice.Switch {
+			running[slice.PID] += slice.Stop - slice.Start
+		}
+	}
+
+	makespan := float64(result.Makespan)
+	for _, row := range result.Rows {
+		utilizations[row.ProcessID] = Utilization{
+			Running: float64(running[row.ProcessID]) / makespan,
+			Waiting: float64(row.Wait) / makespan,
+			Blocked: float64(row.Blocked) / makespan,
+			Absent:  float64(result.Makespan-row.Turnaround) / makespan,
+		}
+	}
+
+	return utilizations
+}
+
+// QueueStateAt returns the IDs of the processes in the ready queue at time t when scheduler runs processes:
+// those that can run by t but have neither completed nor are running at t. They are listed in order of ArrivalTime,
+// with ties in input order, which is not necessarily the order the scheduler would pick them in.
+// The queue is replayed from the schedule's rows and gantt, so a process blocked on I/O at t counts as ready.
+// A process can run from its ArrivalTime, or from its NotBefore if that is later and scheduler is FCFS or SJF,
+// the schedulers that hold to it.
+func QueueStateAt(processes []Process, scheduler Scheduler, t int64) []string {
+	result := scheduler.Schedule(processes)
+	readyAt := func(p Process) int64 { return p.ArrivalTime }
+	switch scheduler.(type) {
+	case FCFS, SJF:
+		readyAt = eligibleAt
+	}
+	completions := make(map[string]int64, len(result.Rows))
+	for _, row := range result.Rows {
+		completions[row.ProcessID] = row.Completion
+	}
+	running := make(map[string]bool)
+	for _, slice := range result.Gantt {
+		if !slice.Switch && slice.Start <= t && t < slice.Stop {
+			running[slice.PID] = true
+		}
+	}
+
+	var ready []string
+	for _, i := range arrivalOrder(processes, TieDefault) {
+		p := processes[i]
+		completion, ok := completions[p.ProcessID]
+		if ok && readyAt(p) <= t && t < completion && !running[p.ProcessID] {
+			ready = append(ready, p.ProcessID)
+		}
+	}
+
+	return ready
+}
+
+// Lateness returns how long after its Deadline each process with one completed in result, keyed by ProcessID.
+// A process that finished early has negative lateness. Processes without a deadline are left out.
+func Lateness(processes []Process, result ScheduleResult) map[string]int64 {
+	completions := make(map[string]int64, len(result.Rows))
+	for _, row := range result.Rows {
+		completions[row.ProcessID] = row.Completion
+	}
+	lateness := make(map[string]int64)
+	for _, p := range processes {
+		if completion, ok := completions[p.ProcessID]; ok && p.Deadline > 0 {
+			lateness[p.ProcessID] = completion - p.Deadline
+		}
+	}
+
+	return lateness
+}
+
+// MaxLateness returns the greatest Lateness of processes in result, and false if none of them has a deadline.
+// Preemptive EDF minimises it on a single CPU, whatever the arrival times.
+func MaxLateness(processes []Process, result ScheduleResult) (int64, bool) {
+	var (
+		latest int64
+		found  bool
+	)
+	for _, lateness := range Lateness(processes, result) {
+		if !found || lateness > latest {
+			latest, found = lateness, true
+		}
+	}
+
+	return latest, found
+}
+
+// AnalyzeStarvation returns the IDs of the processes in result, in row order, whose wait or response time exceeded threshold.
+// It works from the rows alone, so it applies to a schedule from any algorithm.
+func AnalyzeStarvation(result ScheduleResult, threshold int64) []string {
+	var starved []string
+	for _, row := range result.Rows {
+		if row.Wait > threshold || row.Response > threshold {
+			starved = append(starved, row.ProcessID)
+		}
+	}
+
+	return starved
+}
+
+// convoyFactor is how many times longer than a short job's burst both the long job's burst and the short job's wait
+// must be for DetectConvoy to blame the long job.
+const convoyFactor = 3
+
+// Convoy is a long process that held up short ones behind it, as found by DetectConvoy.
+type Convoy struct {
+	// Long is the ProcessID of the long process.
+	Long string
+	// Short are the ProcessIDs of the short processes it held up, in row order.
+	Short []string
+}
+
+// DetectConvoy reports whether result shows the convoy effect FCFS is known for: a long process running to completion
+// while at least two short ones that arrived before it finished queue behind it. A short process has a burst
+// at most 1/convoyFactor of the long one's, first runs after the long one completes, and waits at least
+// convoyFactor times its own burst. If more than one process caused a convoy, the one that held up
+// the most short processes is reported, and the first in row order of those that tie.
+func DetectConvoy(result ScheduleResult) (Convoy, bool) {
+	var worst Convoy
+	for _, long := range result.Rows {
+		convoy := Convoy{Long: long.ProcessID}
+		for _, row := range result.Rows {
+			if row.ProcessID == long.ProcessID || row.BurstDuration*convoyFactor > long.BurstDuration {
+				continue
+			}
+			firstRun := row.ArrivalTime + row.Response
+			if row.ArrivalTime < long.Completion && firstRun >= long.Completion && row.Wait >= convoyFactor*row.BurstDuration {
+				convoy.Short = append(convoy.Short, row.ProcessID)
+			}
+		}
+		if len(convoy.Short) > len(worst.Short) {
+			worst = convoy
+		}
+	}
+	if len(worst.Short) < 2 {
+		return Convoy{}, false
+	}
+
+	return worst, true
+}
+
+//endregion
@@ -0,0 +1,535 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestComputeAverages(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		rows           []ScheduleRow
+		lastCompletion float64
+		wantWait       float64
+		wantTurnaround float64
+		wantThroughput float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "three rows",
+			rows: []ScheduleRow{
+				{Wait: 0, Turnaround: 5},
+				{Wait: 2, Turnaround: 11},
+				{Wait: 8, Turnaround: 14},
+			},
+			lastCompletion: 20,
+			wantWait:       10.0 / 3,
+			wantTurnaround: 10,
+			wantThroughput: 0.15,
+		},
+		{
+			name:           "no time elapsed",
+			rows:           []ScheduleRow{{Wait: 1, Turnaround: 3}},
+			wantWait:       1,
+			wantTurnaround: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wait, turnaround, throughput := ComputeAverages(tt.rows, tt.lastCompletion)
+			if wait != tt.wantWait || turnaround != tt.wantTurnaround || throughput != tt.wantThroughput {
+				t.Errorf("ComputeAverages() = %v, %v, %v, want %v, %v, %v",
+					wait, turnaround, throughput, tt.wantWait, tt.wantTurnaround, tt.wantThroughput)
+			}
+		})
+	}
+}
+
+func TestCPUUsage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		gantt           []TimeSlice
+		wantBusy        int64
+		wantSwitching   int64
+		wantIdle        int64
+		wantUtilization float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "contiguous",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 5},
+				{PID: "P1", Start: 5, Stop: 8},
+			},
+			wantBusy:        8,
+			wantUtilization: 1,
+		},
+		{
+			name: "late start and gap",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 2, Stop: 4},
+				{PID: "P1", Start: 6, Stop: 10},
+			},
+			wantBusy:        6,
+			wantIdle:        4,
+			wantUtilization: 0.6,
+		},
+		{
+			name: "switch cost",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 3},
+				{Start: 3, Stop: 5, Switch: true},
+				{PID: "P1", Start: 5, Stop: 8},
+				{Start: 9, Stop: 10, Switch: true},
+			},
+			wantBusy:        6,
+			wantSwitching:   3,
+			wantIdle:        1,
+			wantUtilization: 0.6,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			busy, switching, idle := CPUUsage(tt.gantt)
+			if busy != tt.wantBusy || switching != tt.wantSwitching || idle != tt.wantIdle {
+				t.Errorf("CPUUsage() = %d, %d, %d, want %d, %d, %d",
+					busy, switching, idle, tt.wantBusy, tt.wantSwitching, tt.wantIdle)
+			}
+			if got := CPUUtilization(tt.gantt); got != tt.wantUtilization {
+				t.Errorf("CPUUtilization() = %v, want %v", got, tt.wantUtilization)
+			}
+		})
+	}
+}
+
+func TestContextSwitches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "idle between different processes",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: "P1", Start: 4, Stop: 6},
+			},
+			want: 1,
+		},
+		{
+			name: "idle between the same process",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: "P0", Start: 4, Stop: 6},
+			},
+		},
+		{
+			name: "switch cost",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: switchLabel, Start: 2, Stop: 3, Switch: true},
+				{PID: "P1", Start: 3, Stop: 5},
+			},
+			want: 1,
+		},
+		{
+			name: "per CPU",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: "P1", Start: 0, Stop: 3, CPU: 1},
+				{PID: "P2", Start: 2, Stop: 4},
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ContextSwitches(tt.gantt); got != tt.want {
+				t.Errorf("ContextSwitches() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRRSchedule_contextSwitches(t *testing.T) {
+	t.Parallel()
+	// With a quantum of 1 the three processes alternate every unit until all 30 units have run.
+	var w bytes.Buffer
+	result := RRSchedule(&w, "Round-robin", 1, []Process{
+		{ProcessID: "P0", BurstDuration: 10},
+		{ProcessID: "P1", BurstDuration: 10},
+		{ProcessID: "P2", BurstDuration: 10},
+	})
+	if got := ContextSwitches(result.Gantt); got != 29 {
+		t.Errorf("ContextSwitches() = %d, want 29", got)
+	}
+	if want := "Context switches: 29\n"; !strings.Contains(w.String(), want) {
+		t.Errorf("missing %q in:\n%s", want, w.String())
+	}
+}
+
+func TestSJFSchedule_idleTime(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	SJFSchedule(&w, "Shortest-job-first", []Process{
+		{ProcessID: "P0", ArrivalTime: 5, BurstDuration: 3},
+	})
+	out := w.String()
+	for _, want := range []string{"Idle time: 5\n", "CPU utilization: 37.50%\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestRRSchedule_switchTime(t *testing.T) {
+	t.Parallel()
+	// A and B alternate with a quantum of 2, paying 1 unit for each of the 3 switches.
+	var w bytes.Buffer
+	RRSchedule(&w, "Round-robin", 2, []Process{
+		{ProcessID: "A", BurstDuration: 3},
+		{ProcessID: "B", BurstDuration: 3},
+	}, WithSwitchCost(1))
+	out := w.String()
+	for _, want := range []string{"Switch time: 3\n", "Idle time: 0\n", "CPU utilization: 66.67%\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestQueueStateAt(t *testing.T) {
+	t.Parallel()
+	// SJF runs P0 0-5, P2 5-6, P3 6-8, and P1 8-11.
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 1},
+		{ProcessID: "P3", ArrivalTime: 6, BurstDuration: 2},
+	}
+	tests := []struct {
+		name string
+		t    int64
+		want []string
+	}{
+		{name: "only the running process has arrived", t: 0},
+		{name: "an arrival waits", t: 1, want: []string{"P1"}},
+		{name: "mid-run", t: 4, want: []string{"P1", "P2"}},
+		{name: "the shortest is dispatched", t: 5, want: []string{"P1"}},
+		{name: "an arrival runs at once", t: 6, want: []string{"P1"}},
+		{name: "the last to run", t: 8},
+		{name: "after the end", t: 11},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(QueueStateAt(processes, SJF{}, tt.t), tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+
+	// Only FCFS and SJF hold P1 back until its NotBefore; RR runs it at 2.
+	held := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 2, NotBefore: 3},
+	}
+	for _, tt := range []struct {
+		scheduler Scheduler
+		want      []string
+	}{
+		{scheduler: FCFS{}},
+		{scheduler: SJF{}},
+		{scheduler: RR{Quantum: 2}, want: []string{"P1"}},
+	} {
+		if diff := cmp.Diff(QueueStateAt(held, tt.scheduler, 1), tt.want); diff != "" {
+			t.Errorf("%T: %s", tt.scheduler, diff)
+		}
+	}
+}
+
+func TestAnalyzeStarvation(t *testing.T) {
+	t.Parallel()
+	// The short jobs keep arriving, so SJF leaves L waiting until they have all run.
+	result := shortestJobFirst([]Process{
+		{ProcessID: "S0", ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: "L", ArrivalTime: 1, BurstDuration: 10},
+		{ProcessID: "S1", ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: "S2", ArrivalTime: 2, BurstDuration: 2},
+		{ProcessID: "S3", ArrivalTime: 3, BurstDuration: 2},
+		{ProcessID: "S4", ArrivalTime: 4, BurstDuration: 2},
+	}, options{})
+	tests := []struct {
+		name      string
+		threshold int64
+		want      []string
+	}{
+		{
+			name:      "long job starved",
+			threshold: 5,
+			want:      []string{"L"},
+		},
+		{
+			name:      "threshold above every wait",
+			threshold: 9,
+		},
+		{
+			name:      "strictly greater than threshold",
+			threshold: 3,
+			want:      []string{"L", "S4"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(AnalyzeStarvation(result, tt.threshold), tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestWaitSpread(t *testing.T) {
+	t.Parallel()
+	// SJF makes P0 wait for both short jobs while round-robin spreads the waiting out, at the same average.
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 1},
+		{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 1},
+	}
+	tests := []struct {
+		name         string
+		result       ScheduleResult
+		wantVariance float64
+	}{
+		{
+			name:         "skewed by SJF",
+			result:       shortestJobFirst(processes, options{}),
+			wantVariance: 26.0 / 9,
+		},
+		{
+			name:         "even under round-robin",
+			result:       roundRobin(processes, 2, options{}),
+			wantVariance: 2.0 / 9,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if tt.result.AveWait != 5.0/3 {
+				t.Fatalf("AveWait = %v, want 5/3", tt.result.AveWait)
+			}
+			variance, stdDev := WaitSpread(tt.result.Rows)
+			if math.Abs(variance-tt.wantVariance) > 1e-9 || math.Abs(stdDev-math.Sqrt(tt.wantVariance)) > 1e-9 {
+				t.Errorf("WaitSpread() = %v, %v, want %v, %v", variance, stdDev, tt.wantVariance, math.Sqrt(tt.wantVariance))
+			}
+		})
+	}
+}
+
+func TestTurnaroundSpread_empty(t *testing.T) {
+	t.Parallel()
+	if variance, stdDev := TurnaroundSpread(nil); variance != 0 || stdDev != 0 {
+		t.Errorf("TurnaroundSpread(nil) = %v, %v, want 0, 0", variance, stdDev)
+	}
+}
+
+func TestWaitPercentiles(t *testing.T) {
+	t.Parallel()
+	rowsOf := func(waits ...int64) []ScheduleRow {
+		rows := make([]ScheduleRow, len(waits))
+		for i, wait := range waits {
+			rows[i] = ScheduleRow{Wait: wait, Turnaround: wait + 1}
+		}
+		return rows
+	}
+	hundred := make([]int64, 100)
+	for i := range hundred {
+		// 100 down to 1, so the rows have to be sorted.
+		hundred[i] = int64(100 - i)
+	}
+	tests := []struct {
+		name string
+		rows []ScheduleRow
+		want Percentiles
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "one to a hundred",
+			rows: rowsOf(hundred...),
+			want: Percentiles{P50: 50, P90: 90, P95: 95, P99: 99},
+		},
+		{
+			name: "long tail",
+			rows: rowsOf(1, 1, 1, 1, 1, 1, 1, 1, 1, 40),
+			want: Percentiles{P50: 1, P90: 1, P95: 40, P99: 40},
+		},
+		{
+			name: "single",
+			rows: rowsOf(7),
+			want: Percentiles{P50: 7, P90: 7, P95: 7, P99: 7},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(WaitPercentiles(tt.rows), tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if len(tt.rows) > 0 {
+				want := Percentiles{P50: tt.want.P50 + 1, P90: tt.want.P90 + 1, P95: tt.want.P95 + 1, P99: tt.want.P99 + 1}
+				if diff := cmp.Diff(TurnaroundPercentiles(tt.rows), want); diff != "" {
+					t.Errorf(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessUtilization(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		schedule  func([]Process) ScheduleResult
+		want      map[string]Utilization
+	}{
+		{
+			name: "fcfs",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: "P1", ArrivalTime: 2, BurstDuration: 4},
+			},
+			schedule: func(processes []Process) ScheduleResult { return firstComeFirstServe(processes, options{}) },
+			// P1 waits 2, then runs 4 to the end at 8.
+			want: map[string]Utilization{
+				"P0": {Running: 0.5, Absent: 0.5},
+				"P1": {Running: 0.5, Waiting: 0.25, Absent: 0.25},
+			},
+		},
+		{
+			name: "io",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 2, Bursts: []BurstSegment{{CPUBurst, 1}, {IOBurst, 2}, {CPUBurst, 1}}},
+			},
+			schedule: firstComeFirstServeIO,
+			// P0 runs 0-1, does I/O 1-3, and runs 3-4.
+			want: map[string]Utilization{
+				"P0": {Running: 0.5, Blocked: 0.5},
+			},
+		},
+		{
+			name: "io overlapping a wait",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 3, Bursts: []BurstSegment{{CPUBurst, 2}, {IOBurst, 3}, {CPUBurst, 1}}},
+				{ProcessID: "P1", BurstDuration: 4, Bursts: []BurstSegment{{CPUBurst, 3}, {IOBurst, 1}, {CPUBurst, 1}}},
+			},
+			schedule: firstComeFirstServeIO,
+			// P0 runs 0-2 and does I/O 2-5 while P1 runs 2-5. P0 runs again 5-6 while P1 does I/O 5-6,
+			// and P1 finishes 6-7. So of the makespan of 7, P0 runs 3, is blocked 3, and has left for 1,
+			// and P1 waits 2, runs 4, and is blocked 1.
+			want: map[string]Utilization{
+				"P0": {Running: 3.0 / 7, Blocked: 3.0 / 7, Absent: 1.0 / 7},
+				"P1": {Running: 4.0 / 7, Waiting: 2.0 / 7, Blocked: 1.0 / 7},
+			},
+		},
+		{
+			name:     "empty",
+			schedule: func([]Process) ScheduleResult { return ScheduleResult{} },
+			want:     map[string]Utilization{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ProcessUtilization(tt.schedule(tt.processes))
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+			for id, b := range got {
+				if sum := b.Running + b.Waiting + b.Blocked + b.Absent; math.Abs(sum-1) > 1e-9 {
+					t.Errorf("%s fractions sum to %v, want 1", id, sum)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectConvoy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      Convoy
+		wantOK    bool
+	}{
+		{
+			name: "long job first",
+			processes: []Process{
+				{ProcessID: "L", ArrivalTime: 0, BurstDuration: 20},
+				{ProcessID: "S0", ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: "S1", ArrivalTime: 2, BurstDuration: 2},
+				{ProcessID: "S2", ArrivalTime: 3, BurstDuration: 1},
+			},
+			want:   Convoy{Long: "L", Short: []string{"S0", "S1", "S2"}},
+			wantOK: true,
+		},
+		{
+			name: "long job last",
+			processes: []Process{
+				{ProcessID: "S0", ArrivalTime: 0, BurstDuration: 1},
+				{ProcessID: "S1", ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: "S2", ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: "L", ArrivalTime: 1, BurstDuration: 20},
+			},
+		},
+		{
+			name: "only one held up",
+			processes: []Process{
+				{ProcessID: "L", ArrivalTime: 0, BurstDuration: 20},
+				{ProcessID: "S0", ArrivalTime: 1, BurstDuration: 1},
+				{ProcessID: "M", ArrivalTime: 2, BurstDuration: 10},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := DetectConvoy(firstComeFirstServe(tt.processes, options{}))
+			if ok != tt.wantOK {
+				t.Errorf("DetectConvoy() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
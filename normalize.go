@@ -0,0 +1,70 @@
+package main
+
+//region Normalization
+
+// NormalizeArrivals returns a copy of processes with every arrival time shifted so the earliest is 0,
+// and the shift that was subtracted, which may be negative. Schedulers count time from 0,
+// so input stamped with epoch times would otherwise start with a long idle block.
+// Deadlines are absolute times too, so each one that is set is shifted by the same amount, and EDF, Lateness,
+// and MaxLateness give the same answers either way. Since a Deadline of 0 reads as no deadline, a deadline
+// no later than the earliest arrival would lose its meaning at 0 or below; the shift is then made smaller,
+// so that the earliest deadline comes out as 1 and the earliest arrival after 0.
+// A NotBefore later than its arrival is shifted as well, and one that has no effect becomes 0.
+// A span between the earliest and latest arrival too wide for an int64 wraps around to a negative arrival,
+// which the schedulers reject.
+func NormalizeArrivals(processes []Process) ([]Process, int64) {
+	if len(processes) == 0 {
+		return processes, 0
+	}
+	shift := processes[0].ArrivalTime
+	for _, p := range processes {
+		shift = min(shift, p.ArrivalTime)
+		if p.Deadline > 0 {
+			shift = min(shift, p.Deadline-1)
+		}
+	}
+
+	normalized := make([]Process, len(processes))
+	for i, p := range processes {
+		p.ArrivalTime -= shift
+		if p.Deadline > 0 {
+			p.Deadline -= shift
+		}
+		if p.NotBefore > p.ArrivalTime+shift {
+			p.NotBefore -= shift
+		} else {
+			// It has no effect, and shifted it could wrap around.
+			p.NotBefore = 0
+		}
+		normalized[i] = p
+	}
+
+	return normalized, shift
+}
+
+// ShiftResult returns a copy of result with every time on the timeline moved shift units later,
+// taking a schedule of processes from NormalizeArrivals back to their original arrival times.
+// Durations such as wait, turnaround, and response are unchanged. Makespan and the averages,
+// average completion included, are left measured from the normalized start.
+// Completions move back with the deadlines NormalizeArrivals shifted, so Lateness of the result against the original
+// processes is the same as that of the normalized result against the normalized processes.
+func ShiftResult(result ScheduleResult, shift int64) ScheduleResult {
+	gantt := make([]TimeSlice, len(result.Gantt))
+	for i, slice := range result.Gantt {
+		slice.Start += shift
+		slice.Stop += shift
+		gantt[i] = slice
+	}
+	rows := make([]ScheduleRow, len(result.Rows))
+	for i, row := range result.Rows {
+		row.ArrivalTime += shift
+		row.Completion += shift
+		rows[i] = row
+	}
+	result.Gantt = gantt
+	result.Rows = rows
+
+	return result
+}
+
+//endregion
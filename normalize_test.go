@@ -0,0 +1,193 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalizeArrivals(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []int64
+		wantShift int64
+	}{
+		{
+			name: "epoch times",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: 105, BurstDuration: 4},
+				{ProcessID: "P1", ArrivalTime: 100, BurstDuration: 3},
+				{ProcessID: "P2", ArrivalTime: 110, BurstDuration: 2},
+			},
+			want:      []int64{5, 0, 10},
+			wantShift: 100,
+		},
+		{
+			name: "negative arrivals",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: -3, BurstDuration: 1},
+				{ProcessID: "P1", ArrivalTime: 2, BurstDuration: 1},
+			},
+			want:      []int64{0, 5},
+			wantShift: -3,
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, shift := NormalizeArrivals(tt.processes)
+			var arrivals []int64
+			for _, p := range got {
+				arrivals = append(arrivals, p.ArrivalTime)
+			}
+			if diff := cmp.Diff(arrivals, tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if shift != tt.wantShift {
+				t.Errorf("shift = %d, want %d", shift, tt.wantShift)
+			}
+			if len(tt.processes) > 0 && tt.processes[0].ArrivalTime == got[0].ArrivalTime && shift != 0 {
+				t.Errorf("the caller's processes were modified")
+			}
+		})
+	}
+}
+
+func TestShiftResult(t *testing.T) {
+	t.Parallel()
+	processes, shift := NormalizeArrivals([]Process{
+		{ProcessID: "P0", ArrivalTime: 100, BurstDuration: 4},
+		{ProcessID: "P1", ArrivalTime: 105, BurstDuration: 3},
+		{ProcessID: "P2", ArrivalTime: 110, BurstDuration: 2},
+	})
+	normalized := shortestJobFirst(processes, options{})
+	got := ShiftResult(normalized, shift)
+
+	wantGantt := []TimeSlice{
+		{PID: "P0", Start: 100, Stop: 104},
+		{PID: "P1", Start: 105, Stop: 108},
+		{PID: "P2", Start: 110, Stop: 112},
+	}
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+	var completions []int64
+	for _, row := range got.Rows {
+		completions = append(completions, row.Completion)
+	}
+	if diff := cmp.Diff(completions, []int64{104, 108, 112}); diff != "" {
+		t.Errorf(diff)
+	}
+	if got.AveWait != normalized.AveWait || got.AveTurnaround != normalized.AveTurnaround {
+		t.Errorf("averages changed: %v, %v, want %v, %v", got.AveWait, got.AveTurnaround, normalized.AveWait, normalized.AveTurnaround)
+	}
+	if normalized.Gantt[0].Start != 0 {
+		t.Errorf("the normalized result was modified")
+	}
+}
+
+func TestNormalizeArrivals_deadlines(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 1000, BurstDuration: 4},
+		{ProcessID: "B", ArrivalTime: 1000, BurstDuration: 3, Deadline: 1010},
+		{ProcessID: "C", ArrivalTime: 1001, BurstDuration: 2, Deadline: 1004},
+		{ProcessID: "D", ArrivalTime: 1002, BurstDuration: 3, Deadline: 1005},
+	}
+	normalized, shift := NormalizeArrivals(processes)
+	var deadlines []int64
+	for _, p := range normalized {
+		deadlines = append(deadlines, p.Deadline)
+	}
+	// A stays without a deadline.
+	if diff := cmp.Diff(deadlines, []int64{0, 10, 4, 5}); diff != "" {
+		t.Errorf(diff)
+	}
+
+	want := earliestDeadline(processes)
+	got := earliestDeadline(normalized)
+	if diff := cmp.Diff(ShiftResult(got, shift).Gantt, want.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if got.DeadlineMisses != want.DeadlineMisses {
+		t.Errorf("DeadlineMisses = %d, want %d", got.DeadlineMisses, want.DeadlineMisses)
+	}
+	if diff := cmp.Diff(Lateness(normalized, got), Lateness(processes, want)); diff != "" {
+		t.Errorf(diff)
+	}
+	if diff := cmp.Diff(Lateness(processes, ShiftResult(got, shift)), Lateness(processes, want)); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestNormalizeArrivals_deadlineAtFirstArrival(t *testing.T) {
+	t.Parallel()
+	// A's deadline is the earliest arrival, so shifting it to 0 would drop it and let B run first.
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 1000, BurstDuration: 2, Deadline: 1000},
+		{ProcessID: "B", ArrivalTime: 1000, BurstDuration: 1, Deadline: 1003},
+		{ProcessID: "C", ArrivalTime: 1001, BurstDuration: 1},
+	}
+	normalized, shift := NormalizeArrivals(processes)
+	if shift != 999 {
+		t.Errorf("shift = %d, want 999", shift)
+	}
+	if diff := cmp.Diff(normalized, []Process{
+		{ProcessID: "A", ArrivalTime: 1, BurstDuration: 2, Deadline: 1},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 1, Deadline: 4},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 1},
+	}); diff != "" {
+		t.Errorf(diff)
+	}
+
+	want := earliestDeadline(processes)
+	got := earliestDeadline(normalized)
+	if diff := cmp.Diff(ShiftResult(got, shift).Gantt, want.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if got.DeadlineMisses != want.DeadlineMisses {
+		t.Errorf("DeadlineMisses = %d, want %d", got.DeadlineMisses, want.DeadlineMisses)
+	}
+	if diff := cmp.Diff(Lateness(normalized, got), Lateness(processes, want)); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestNormalizeArrivals_notBefore(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 1_000_000, BurstDuration: 4},
+		{ProcessID: "P1", ArrivalTime: 1_000_001, BurstDuration: 2, NotBefore: 1_000_006},
+		{ProcessID: "P2", ArrivalTime: 1_000_002, BurstDuration: 1, NotBefore: 1_000_002},
+		{ProcessID: "P3", ArrivalTime: 1_000_003, BurstDuration: 1, NotBefore: 5},
+	}
+	normalized, shift := NormalizeArrivals(processes)
+	var notBefores []int64
+	for _, p := range normalized {
+		notBefores = append(notBefores, p.NotBefore)
+	}
+	// Only P1's NotBefore holds it back, and the others no longer have any.
+	if diff := cmp.Diff(notBefores, []int64{0, 6, 0, 0}); diff != "" {
+		t.Errorf(diff)
+	}
+	for i, p := range normalized {
+		if eligibleAt(p)+shift != eligibleAt(processes[i]) {
+			t.Errorf("%q is eligible at %d, want %d", p.ProcessID, eligibleAt(p), eligibleAt(processes[i])-shift)
+		}
+	}
+
+	want := firstComeFirstServe(processes, options{})
+	got := firstComeFirstServe(normalized, options{})
+	if diff := cmp.Diff(ShiftResult(got, shift).Gantt, want.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if err := ValidateGanttBursts(got.Gantt, normalized); err != nil {
+		t.Errorf("normalized schedule is not valid: %v", err)
+	}
+}
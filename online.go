@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+//region Online scheduling
+
+// FCFSScheduleOnline outputs and returns a first-come, first-serve schedule of processes received from arrivals
+// as they arrive, rather than from a list known up front. Each process's slice is decided as soon as it is received
+// and sent straight to slices, if slices is not nil, which is closed when FCFSScheduleOnline returns.
+// The table is written once arrivals is closed.
+//
+// Processes must be sent in arrival order, those arriving together in the order they should run.
+// A process that could not be scheduled, or that arrives before one already received, is reported to w,
+// and the rest of arrivals is drained and ignored so that senders are not left blocked.
+func FCFSScheduleOnline(w io.Writer, title string, arrivals <-chan Process, slices chan<- TimeSlice) ScheduleResult {
+	if slices != nil {
+		defer close(slices)
+	}
+
+	var (
+		serviceTime int64
+		processes   []Process
+		schedule    []ScheduleRow
+		gantt       = make([]TimeSlice, 0)
+		seen        = make(map[string]bool)
+	)
+	for p := range arrivals {
+		var err error
+		switch {
+		case p.BurstDuration <= 0:
+			err = fmt.Errorf("%w: %q has a burst duration %d that is not positive", ErrInvalidProcess, p.ProcessID, p.BurstDuration)
+		case p.ArrivalTime < 0:
+			err = fmt.Errorf("%w: %q has a negative arrival time %d", ErrInvalidProcess, p.ProcessID, p.ArrivalTime)
+		case seen[p.ProcessID]:
+			err = fmt.Errorf("%w: %q is used by more than one process", ErrInvalidProcess, p.ProcessID)
+		case len(processes) > 0 && p.ArrivalTime < processes[len(processes)-1].ArrivalTime:
+			err = fmt.Errorf("%w: %q arrives at %d, before %q received ahead of it", ErrInvalidProcess,
+				p.ProcessID, p.ArrivalTime, processes[len(processes)-1].ProcessID)
+		}
+		start := serviceTime
+		if p.ArrivalTime > start {
+			start = p.ArrivalTime
+		}
+		completion, ok := checkedAdd(start, p.BurstDuration)
+		if err == nil && !ok {
+			err = fmt.Errorf("%w: %q would complete after %d", ErrOverflow, p.ProcessID, int64(math.MaxInt64))
+		}
+		if err != nil {
+			_, _ = fmt.Fprintln(w, err)
+			for range arrivals {
+				// Drain the rest so senders are not left blocked.
+			}
+			return ScheduleResult{Title: title}
+		}
+		seen[p.ProcessID] = true
+		processes = append(processes, p)
+
+		slice := TimeSlice{PID: p.ProcessID, Start: start, Stop: completion}
+		gantt = append(gantt, slice)
+		if slices != nil {
+			slices <- slice
+		}
+		schedule = append(schedule, ScheduleRow{
+			ProcessID:     p.ProcessID,
+			Priority:      p.Priority,
+			BurstDuration: p.BurstDuration,
+			ArrivalTime:   p.ArrivalTime,
+			Wait:          start - p.ArrivalTime,
+			Turnaround:    completion - p.ArrivalTime,
+			Completion:    completion,
+			Response:      start - p.ArrivalTime,
+		})
+		serviceTime = completion
+	}
+	if len(processes) == 0 {
+		_, _ = fmt.Fprintln(w, "no processes to schedule")
+		return ScheduleResult{Title: title}
+	}
+
+	result := newScheduleResult(gantt, schedule)
+	result.Title = title
+	outputResult(w, result)
+
+	return result
+}
+
+//endregion
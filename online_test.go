@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFCFSScheduleOnline(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: "P2", ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	arrivals := make(chan Process)
+	slices := make(chan TimeSlice)
+	go func() {
+		for _, p := range processes {
+			arrivals <- p
+		}
+		close(arrivals)
+	}()
+	done := make(chan ScheduleResult)
+	var online, batch bytes.Buffer
+	go func() { done <- FCFSScheduleOnline(&online, "First-come, first-serve", arrivals, slices) }()
+
+	var streamed []TimeSlice
+	for slice := range slices {
+		streamed = append(streamed, slice)
+	}
+	got := <-done
+
+	want := FCFSSchedule(&batch, "First-come, first-serve", processes)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf(diff)
+	}
+	if diff := cmp.Diff(online.String(), batch.String()); diff != "" {
+		t.Errorf(diff)
+	}
+	if diff := cmp.Diff(streamed, want.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestFCFSScheduleOnline_invalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantOut   string
+	}{
+		{
+			name:    "no processes",
+			wantOut: "no processes to schedule\n",
+		},
+		{
+			name: "out of order",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: 4, BurstDuration: 1},
+				{ProcessID: "P1", ArrivalTime: 2, BurstDuration: 1},
+				{ProcessID: "P2", ArrivalTime: 5, BurstDuration: 1},
+			},
+			wantOut: "invalid process: \"P1\" arrives at 2, before \"P0\" received ahead of it\n",
+		},
+		{
+			name:      "zero burst",
+			processes: []Process{{ProcessID: "P0"}},
+			wantOut:   "invalid process: \"P0\" has a burst duration 0 that is not positive\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Buffered, so the test does not block even if the rest were not drained.
+			arrivals := make(chan Process, len(tt.processes))
+			for _, p := range tt.processes {
+				arrivals <- p
+			}
+			close(arrivals)
+			var w bytes.Buffer
+			got := FCFSScheduleOnline(&w, "t", arrivals, nil)
+			if diff := cmp.Diff(w.String(), tt.wantOut); diff != "" {
+				t.Errorf(diff)
+			}
+			if got.Rows != nil || len(arrivals) != 0 {
+				t.Errorf("got %d rows with %d processes left unread", len(got.Rows), len(arrivals))
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+//region Scheduler options
+
+// Option configures optional scheduler behavior. Schedulers ignore options that do not apply to them.
+type Option func(*options)
+
+type options struct {
+	switchCost    int64
+	agingInterval int64
+	tieBreak      TieBreak
+	quanta        []int64
+	trace         io.Writer
+	warmUp        int64
+	priorityOrder PriorityOrder
+	lookahead     bool
+	waitCap       int64
+	zeroBursts    ZeroBursts
+	quiet         bool
+	gantt         []GanttOption
+	columns       ColumnSet
+	rowOrder      RowOrder
+	figures       []ScheduleOption
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithSwitchCost charges cost time units each time the CPU goes straight from one process to a different one.
+// The cost is not charged for the first dispatch or after the CPU has been idle,
+// and it shows up in the gantt as a block with Switch set.
+func WithSwitchCost(cost int64) Option {
+	return func(o *options) {
+		o.switchCost = cost
+	}
+}
+
+// WithAging makes preemptive priority scheduling boost a ready process's priority by one step
+// for every interval time units it waits, so a stream of high-priority arrivals cannot starve it.
+// The boost is reset whenever the process runs.
+func WithAging(interval int64) Option {
+	return func(o *options) {
+		o.agingInterval = interval
+	}
+}
+
+// WithQuanta gives round-robin a quantum per round instead of its single quantum: a process's first dispatch
+// runs for up to quanta[0], its second for up to quanta[1], and so on, starting again from quanta[0]
+// once a process has used them all. Every quantum must be greater than 0.
+func WithQuanta(quanta ...int64) Option {
+	return func(o *options) {
+		o.quanta = quanta
+	}
+}
+
+// WithTrace writes a line to w for every choice SJF, SRTF, and Priority make between ready processes,
+// naming the process picked and the ones passed over along with what they were ranked by, such as
+// "t=5 selected P3 (burst=2) over P2 (burst=4)". SRTF and preemptive Priority write only when they dispatch a
+// different process from the one running. A nil w writes nothing.
+func WithTrace(w io.Writer) Option {
+	return func(o *options) {
+		o.trace = w
+	}
+}
+
+// WithWarmUp holds off FCFS and SJF from dispatching anything until time warmUp, so a batch of processes can arrive
+// before the first is picked. The CPU is idle until then, and processes that arrived earlier wait for it.
+// A warm-up that is not positive has no effect.
+func WithWarmUp(warmUp int64) Option {
+	return func(o *options) {
+		o.warmUp = warmUp
+	}
+}
+
+// WithLookahead lets SJF see arrivals coming up, which a real scheduler cannot, for offline analysis.
+// Before starting the shortest ready job, SJF checks each shorter job that becomes eligible while it would run,
+// and leaves the CPU idle for that job instead if running it first gives a lower total waiting time.
+// This is not standard SJF, so results with it are not comparable with the online schedulers.
+func WithLookahead() Option {
+	return func(o *options) {
+		o.lookahead = true
+	}
+}
+
+// WithWaitCap bounds how long SJF lets a job wait: once a ready job has waited more than waitCap,
+// it runs next whatever its burst, the longest waiting first. A cap that is not greater than 0 has no effect.
+func WithWaitCap(waitCap int64) Option {
+	return func(o *options) {
+		o.waitCap = waitCap
+	}
+}
+
+// TieBreak orders processes that arrive at the same time and that a scheduler otherwise ranks equal:
+// simultaneous arrivals in FCFS, simultaneous arrivals of equal priority in Priority, and equal bursts in SJF.
+type TieBreak int
+
+const (
+	// TieDefault keeps each scheduler's own rule, which is input order except in SJF, where it is by ID.
+	TieDefault TieBreak = iota
+	// TieByInputOrder takes the process given first.
+	TieByInputOrder
+	// TieByID takes the lexicographically smaller ProcessID.
+	TieByID
+	// TieByPriority takes the higher priority process, that is the lower Priority, and then the one given first.
+	TieByPriority
+)
+
+// WithTieBreak sets how FCFS, SJF, and Priority order processes they rank equal.
+// SRTF goes by it too for processes that arrive together with the same burst.
+func WithTieBreak(tie TieBreak) Option {
+	return func(o *options) {
+		o.tieBreak = tie
+	}
+}
+
+// PriorityOrder says which way Priority values rank in PrioritySchedule, since textbooks disagree.
+type PriorityOrder int
+
+const (
+	// DescendingIsHigher ranks priority higher as the number goes down, like Unix nice values,
+	// so a process with priority 1 runs before one with priority 2. It is the default.
+	DescendingIsHigher PriorityOrder = iota
+	// AscendingIsHigher ranks priority higher as the number goes up, so priority 2 runs before priority 1.
+	AscendingIsHigher
+)
+
+// WithPriorityOrder sets which way PrioritySchedule ranks Priority values, with or without preemption.
+// Aging still moves a waiting process towards the higher priority. TieByPriority is unaffected.
+func WithPriorityOrder(order PriorityOrder) Option {
+	return func(o *options) {
+		o.priorityOrder = order
+	}
+}
+
+// ZeroBursts says what FCFSSchedule, SJFSchedule, PrioritySchedule, SRTFSchedule, and RRSchedule do
+// with processes whose BurstDuration is 0, such as markers in a trace.
+type ZeroBursts int
+
+const (
+	// ZeroBurstsRejected fails validation as ValidateProcesses does. It is the default.
+	ZeroBurstsRejected ZeroBursts = iota
+	// ZeroBurstsSkipped leaves them out of the schedule, so they have no rows and do not count towards the averages.
+	ZeroBurstsSkipped
+	// ZeroBurstsInstant completes each one the moment it arrives, without waiting, in a row of its own but without a slice.
+	// They count towards the averages, and one arriving after the other work ends extends the makespan.
+	ZeroBurstsInstant
+)
+
+// WithZeroBursts sets how processes with no burst are handled instead of rejecting them.
+// The other processes are scheduled as if they were not there.
+func WithZeroBursts(zero ZeroBursts) Option {
+	return func(o *options) {
+		o.zeroBursts = zero
+	}
+}
+
+// WithQuiet makes FCFSSchedule, SJFSchedule, SJFPrioritySchedule, PrioritySchedule, SRTFSchedule, and RRSchedule write only
+// the one-line summary of outputSummary in place of the title, chart, and table, to keep logs of many runs compact.
+// Invalid input is still explained.
+func WithQuiet() Option {
+	return func(o *options) {
+		o.quiet = true
+	}
+}
+
+// WithGanttOptions draws the gantt chart of the schedulers that write one with opts, such as WithScale.
+func WithGanttOptions(opts ...GanttOption) Option {
+	return func(o *options) {
+		o.gantt = append(o.gantt, opts...)
+	}
+}
+
+// WithColumns draws the schedule table of the schedulers that write one with columns instead of DefaultColumns.
+func WithColumns(columns ColumnSet) Option {
+	return func(o *options) {
+		o.columns = columns
+	}
+}
+
+// WithRowOrder draws the rows of the schedule table in order instead of in input order.
+func WithRowOrder(order RowOrder) Option {
+	return func(o *options) {
+		o.rowOrder = order
+	}
+}
+
+// WithScheduleOptions writes the figures below the schedule table of the schedulers that write one with opts,
+// such as WithDecimals.
+func WithScheduleOptions(opts ...ScheduleOption) Option {
+	return func(o *options) {
+		o.figures = append(o.figures, opts...)
+	}
+}
+
+// schedulable is schedulable allowing zero bursts unless o rejects them.
+func (o options) schedulable(w io.Writer, processes []Process) bool {
+	if o.zeroBursts == ZeroBurstsRejected || len(processes) == 0 {
+		return schedulable(w, processes)
+	}
+	if err := validateProcesses(processes, true); err != nil {
+		_, _ = fmt.Fprintln(w, err)
+		return false
+	}
+
+	return true
+}
+
+// schedule returns run's schedule of processes, leaving out or instantly completing any zero bursts as o says.
+// Rows stay in input order.
+func (o options) schedule(processes []Process, run func([]Process) ScheduleResult) ScheduleResult {
+	if o.zeroBursts == ZeroBurstsRejected {
+		return run(processes)
+	}
+
+	rest := make([]Process, 0, len(processes))
+	for _, p := range processes {
+		if p.BurstDuration != 0 {
+			rest = append(rest, p)
+		}
+	}
+	var result ScheduleResult
+	if len(rest) > 0 {
+		result = run(rest)
+	}
+	if o.zeroBursts == ZeroBurstsSkipped || len(rest) == len(processes) {
+		return result
+	}
+
+	rows := make([]ScheduleRow, 0, len(processes))
+	r := 0
+	for _, p := range processes {
+		if p.BurstDuration != 0 {
+			rows = append(rows, result.Rows[r])
+			r++
+			continue
+		}
+		rows = append(rows, ScheduleRow{
+			ProcessID:   p.ProcessID,
+			Priority:    p.Priority,
+			ArrivalTime: p.ArrivalTime,
+			Completion:  p.ArrivalTime,
+		})
+	}
+	merged := newScheduleResult(result.Gantt, rows)
+	result.Gantt, result.Rows, result.Makespan = merged.Gantt, merged.Rows, merged.Makespan
+	result.AveWait, result.AveTurnaround, result.Throughput = merged.AveWait, merged.AveTurnaround, merged.Throughput
+	result.AveResponse, result.AveCompletion = merged.AveResponse, merged.AveCompletion
+
+	return result
+}
+
+// outranks reports whether priority a is higher than priority b under o's PriorityOrder.
+func (o options) outranks(a, b int64) bool {
+	if o.priorityOrder == AscendingIsHigher {
+		return a > b
+	}
+	return a < b
+}
+
+// tieLess reports whether processes[i] goes before processes[j] when a scheduler ranks them equal.
+func tieLess(tie TieBreak, processes []Process, i, j int) bool {
+	switch tie {
+	case TieByID:
+		return processes[i].ProcessID < processes[j].ProcessID
+	case TieByPriority:
+		if processes[i].Priority != processes[j].Priority {
+			return processes[i].Priority < processes[j].Priority
+		}
+	}
+	return i < j
+}
+
+// contextSwitch appends a switch block to gantt and advances serviceTime past it
+// when the CPU goes straight from another process to pid.
+func contextSwitch(gantt []TimeSlice, pid string, serviceTime, cost int64) ([]TimeSlice, int64) {
+	last := len(gantt) - 1
+	if cost <= 0 || last < 0 || gantt[last].Stop != serviceTime || gantt[last].PID == pid {
+		return gantt, serviceTime
+	}
+
+	return append(gantt, TimeSlice{Start: serviceTime, Stop: serviceTime + cost, Switch: true}), serviceTime + cost
+}
+
+//endregion
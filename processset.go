@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+//region Process sets
+
+// ProcessSet is a validated copy of some processes, normalized with NormalizeArrivals and sorted by arrival
+// with ties in input order. Its schedules can be run without checking the input again,
+// and their rows come out in arrival order.
+type ProcessSet struct {
+	processes []Process
+	shift     int64
+}
+
+// NewProcessSet validates processes with ValidateProcesses and stores a copy of them normalized
+// with NormalizeArrivals and sorted. An empty slice is an ErrInvalidProcess error, since there would be nothing to schedule.
+func NewProcessSet(processes []Process) (*ProcessSet, error) {
+	if len(processes) == 0 {
+		return nil, fmt.Errorf("%w: no processes to schedule", ErrInvalidProcess)
+	}
+	if err := ValidateProcesses(processes); err != nil {
+		return nil, err
+	}
+
+	sorted, shift := NormalizeArrivals(cloneProcesses(processes))
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ArrivalTime < sorted[j].ArrivalTime })
+
+	return &ProcessSet{processes: sorted, shift: shift}, nil
+}
+
+// Processes returns a copy of the normalized, sorted processes.
+func (s *ProcessSet) Processes() []Process {
+	return cloneProcesses(s.processes)
+}
+
+// Shift returns the time subtracted from every arrival, which ShiftResult adds back to the set's schedules.
+func (s *ProcessSet) Shift() int64 {
+	return s.shift
+}
+
+// FCFS outputs and returns FCFSSchedule of the set.
+func (s *ProcessSet) FCFS(w io.Writer, title string, opts ...Option) ScheduleResult {
+	return FCFSSchedule(w, title, s.processes, opts...)
+}
+
+// SJF outputs and returns SJFSchedule of the set.
+func (s *ProcessSet) SJF(w io.Writer, title string, opts ...Option) ScheduleResult {
+	return SJFSchedule(w, title, s.processes, opts...)
+}
+
+// Schedule returns the schedule of the set computed by scheduler.
+func (s *ProcessSet) Schedule(scheduler Scheduler) ScheduleResult {
+	return scheduler.Schedule(s.processes)
+}
+
+//endregion
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewProcessSet(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   error
+		wantShift int64
+		want      []Process
+	}{
+		{
+			name:    "empty",
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "invalid",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 1},
+				{ProcessID: "P1", BurstDuration: 0},
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "sorted stably",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: 3, BurstDuration: 1},
+				{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: "P2", ArrivalTime: 3, BurstDuration: 3},
+			},
+			want: []Process{
+				{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: "P0", ArrivalTime: 3, BurstDuration: 1},
+				{ProcessID: "P2", ArrivalTime: 3, BurstDuration: 3},
+			},
+		},
+		{
+			name: "normalized",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: 1005, BurstDuration: 1},
+				{ProcessID: "P1", ArrivalTime: 1000, BurstDuration: 2, Deadline: 1010},
+			},
+			wantShift: 1000,
+			want: []Process{
+				{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 2, Deadline: 10},
+				{ProcessID: "P0", ArrivalTime: 5, BurstDuration: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			set, err := NewProcessSet(tt.processes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewProcessSet() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if set != nil {
+					t.Errorf("NewProcessSet() = %v with an error", set)
+				}
+				return
+			}
+			if diff := cmp.Diff(set.Processes(), tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if set.Shift() != tt.wantShift {
+				t.Errorf("Shift() = %d, want %d", set.Shift(), tt.wantShift)
+			}
+		})
+	}
+}
+
+func TestNewProcessSet_bursts(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", BurstDuration: 3, Bursts: []BurstSegment{
+			{Kind: CPUBurst, Duration: 1}, {Kind: IOBurst, Duration: 4}, {Kind: CPUBurst, Duration: 2},
+		}},
+	}
+	want := cloneProcesses(processes)
+	set, err := NewProcessSet(processes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Neither the input nor a copy handed out shares its Bursts with the set.
+	processes[0].Bursts[1].Duration = 100
+	set.Processes()[0].Bursts[2].Duration = 100
+	if diff := cmp.Diff(set.Processes(), want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestProcessSet_schedules(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 1},
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 2},
+	}
+	set, err := NewProcessSet(processes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Changing the input afterwards does not reach the set.
+	processes[0].BurstDuration = 100
+
+	fcfs := []ScheduleRow{
+		{ProcessID: "P0", BurstDuration: 4, Turnaround: 4, Completion: 4},
+		{ProcessID: "P1", BurstDuration: 2, ArrivalTime: 1, Wait: 3, Turnaround: 5, Completion: 6, Response: 3},
+		{ProcessID: "P2", BurstDuration: 1, ArrivalTime: 2, Wait: 4, Turnaround: 5, Completion: 7, Response: 4},
+	}
+	sjf := []ScheduleRow{
+		{ProcessID: "P0", BurstDuration: 4, Turnaround: 4, Completion: 4},
+		{ProcessID: "P1", BurstDuration: 2, ArrivalTime: 1, Wait: 4, Turnaround: 6, Completion: 7, Response: 4},
+		{ProcessID: "P2", BurstDuration: 1, ArrivalTime: 2, Wait: 2, Turnaround: 3, Completion: 5, Response: 2},
+	}
+	tests := []struct {
+		name string
+		got  ScheduleResult
+		want []ScheduleRow
+	}{
+		{name: "FCFS", got: set.FCFS(io.Discard, "First-come, first-serve"), want: fcfs},
+		{name: "SJF", got: set.SJF(io.Discard, "Shortest-job-first"), want: sjf},
+		{name: "Schedule", got: set.Schedule(FCFS{}), want: fcfs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.got.Rows, tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+)
+
+// propertySchedulers are the work-conserving schedulers the property tests run, keyed by name.
+var propertySchedulers = map[string]func([]Process) ScheduleResult{
+	"FCFS":               func(processes []Process) ScheduleResult { return firstComeFirstServe(processes, options{}) },
+	"SJF":                func(processes []Process) ScheduleResult { return shortestJobFirst(processes, options{}) },
+	"RR":                 func(processes []Process) ScheduleResult { return roundRobin(processes, 3, options{}) },
+	"SRTF":               func(processes []Process) ScheduleResult { return shortestRemainingTime(processes, options{}) },
+	"Priority":           func(processes []Process) ScheduleResult { return highestPriority(processes, options{}) },
+	"PreemptivePriority": func(processes []Process) ScheduleResult { return preemptivePriority(processes, options{}) },
+	"SJFPriority":        sjfPriority,
+}
+
+// propertyWorkloads returns random workloads of a few shapes for the property tests:
+// sparse arrivals that leave the CPU idle, a burst of simultaneous arrivals, and everything in between.
+func propertyWorkloads() [][]Process {
+	var workloads [][]Process
+	for seed := int64(1); seed <= 20; seed++ {
+		workloads = append(workloads,
+			GenerateProcesses(30, seed, GenOptions{}),
+			GenerateProcesses(30, seed, GenOptions{MaxArrival: 1000}),
+			GenerateProcesses(30, seed, GenOptions{MaxArrival: 1}),
+			GenerateProcesses(1, seed, GenOptions{MinArrival: 5, MaxArrival: 50}))
+	}
+
+	return workloads
+}
+
+func TestProperty_busyTime(t *testing.T) {
+	t.Parallel()
+	for name, schedule := range propertySchedulers {
+		name, schedule := name, schedule
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for _, processes := range propertyWorkloads() {
+				var bursts int64
+				for _, p := range processes {
+					bursts += p.BurstDuration
+				}
+				result := schedule(processes)
+
+				if err := ValidateGanttBursts(result.Gantt, processes); err != nil {
+					t.Fatalf("%v: %s of %v", err, FormatTimeSlices(result.Gantt), processes)
+				}
+				if result.Makespan < bursts {
+					t.Fatalf("makespan %d is less than the bursts total %d: %s of %v",
+						result.Makespan, bursts, FormatTimeSlices(result.Gantt), processes)
+				}
+			}
+		})
+	}
+}
+
+func TestProperty_turnaround(t *testing.T) {
+	t.Parallel()
+	for name, schedule := range propertySchedulers {
+		name, schedule := name, schedule
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for _, processes := range propertyWorkloads() {
+				result := schedule(processes)
+				if len(result.Rows) != len(processes) {
+					t.Fatalf("got %d rows for %d processes %v", len(result.Rows), len(processes), processes)
+				}
+				for i, row := range result.Rows {
+					if row.Turnaround != row.Wait+row.BurstDuration || row.Completion != row.ArrivalTime+row.Turnaround {
+						t.Fatalf("%+v of %+v: turnaround must be wait + burst and completion arrival + turnaround, in %v",
+							row, processes[i], processes)
+					}
+					if row.Wait < 0 || row.Response < 0 || row.Response > row.Wait {
+						t.Fatalf("%+v of %+v: wait and response must not be negative, nor response more than wait, in %v",
+							row, processes[i], processes)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//region Scheduler interface
+
+// Scheduler computes a schedule without writing anything, so any algorithm can be plugged into generic tooling.
+// Processes that ValidateProcesses rejects give a result with no rows, so validate them first to learn why.
+// Like every scheduler in this package, it works on its own copy of processes and never modifies the caller's slice,
+// so the same slice can be passed to one algorithm after another.
+type Scheduler interface {
+	Schedule(processes []Process) ScheduleResult
+}
+
+type (
+	// FCFS is the Scheduler of FCFSSchedule.
+	FCFS struct{}
+	// SJF is the Scheduler of SJFSchedule.
+	SJF struct{}
+	// SJFPriority is the Scheduler of SJFPrioritySchedule.
+	SJFPriority struct{}
+	// SRTF is the Scheduler of SRTFSchedule.
+	SRTF struct{}
+	// Priority is the Scheduler of PrioritySchedule.
+	Priority struct {
+		Preemptive bool
+	}
+	// RR is the Scheduler of RRSchedule. A Quantum that is not greater than 0 gives a result with no rows.
+	RR struct {
+		Quantum int64
+	}
+)
+
+var (
+	_ Scheduler = FCFS{}
+	_ Scheduler = SJF{}
+	_ Scheduler = SJFPriority{}
+	_ Scheduler = SRTF{}
+	_ Scheduler = Priority{}
+	_ Scheduler = RR{}
+)
+
+func (FCFS) Schedule(processes []Process) ScheduleResult {
+	return FCFSSchedule(io.Discard, "First-come, first-serve", processes)
+}
+
+func (SJF) Schedule(processes []Process) ScheduleResult {
+	return SJFSchedule(io.Discard, "Shortest-job-first", processes)
+}
+
+func (SJFPriority) Schedule(processes []Process) ScheduleResult {
+	return SJFPrioritySchedule(io.Discard, "Shortest-job-first priority", processes)
+}
+
+func (SRTF) Schedule(processes []Process) ScheduleResult {
+	return SRTFSchedule(io.Discard, "Shortest-remaining-time-first", processes)
+}
+
+func (s Priority) Schedule(processes []Process) ScheduleResult {
+	title := "Priority"
+	if s.Preemptive {
+		title = "Preemptive priority"
+	}
+	return PrioritySchedule(io.Discard, title, processes, s.Preemptive)
+}
+
+func (s RR) Schedule(processes []Process) ScheduleResult {
+	return RRSchedule(io.Discard, "Round-robin", s.Quantum, processes)
+}
+
+//endregion
+
+//region Scheduler registry
+
+var (
+	ErrUnknownScheduler = errors.New("unknown scheduler")
+	ErrSchedulerParams  = errors.New("invalid scheduler parameters")
+)
+
+// SchedulerFactory builds a Scheduler from string parameters, returning an ErrSchedulerParams error if they are not valid.
+type SchedulerFactory func(params map[string]string) (Scheduler, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]SchedulerFactory{
+		"fcfs": func(map[string]string) (Scheduler, error) { return FCFS{}, nil },
+		"sjf":  func(map[string]string) (Scheduler, error) { return SJF{}, nil },
+		"sjfp": func(map[string]string) (Scheduler, error) { return SJFPriority{}, nil },
+		"srtf": func(map[string]string) (Scheduler, error) { return SRTF{}, nil },
+		"priority": func(params map[string]string) (Scheduler, error) {
+			var s Priority
+			if value, ok := params["preemptive"]; ok {
+				preemptive, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("%w: priority preemptive %q is not a boolean", ErrSchedulerParams, value)
+				}
+				s.Preemptive = preemptive
+			}
+			return s, nil
+		},
+		"rr": func(params map[string]string) (Scheduler, error) {
+			value, ok := params["quantum"]
+			if !ok {
+				return nil, fmt.Errorf("%w: rr requires a quantum", ErrSchedulerParams)
+			}
+			quantum, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || quantum <= 0 {
+				return nil, fmt.Errorf("%w: rr quantum %q must be an integer greater than 0", ErrSchedulerParams, value)
+			}
+			return RR{Quantum: quantum}, nil
+		},
+	}
+)
+
+// Register makes a Scheduler available to NewScheduler as name, built by factory.
+// The built-in schedulers are fcfs, sjf, sjfp, srtf, priority (with an optional boolean "preemptive"),
+// and rr (with a required "quantum"). Register panics if name is already taken or factory is nil.
+func Register(name string, factory SchedulerFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if factory == nil {
+		panic("Register: nil factory for scheduler " + name)
+	}
+	if _, ok := registry[name]; ok {
+		panic("Register: scheduler " + name + " is already registered")
+	}
+	registry[name] = factory
+}
+
+// NewScheduler builds the Scheduler registered as name from params. The command line picks its -algorithm with it.
+// It returns an ErrUnknownScheduler error listing the registered names if there is none by that name,
+// and the factory's error if params are not valid for it.
+func NewScheduler(name string, params map[string]string) (Scheduler, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q, must be one of: %s", ErrUnknownScheduler, name, strings.Join(SchedulerNames(), ", "))
+	}
+
+	return factory(params)
+}
+
+// SchedulerNames returns the registered scheduler names in sorted order.
+func SchedulerNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+//endregion
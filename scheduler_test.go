@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScheduler(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+	}
+	tests := []struct {
+		scheduler Scheduler
+		wantTitle string
+		wantOrder []string
+	}{
+		{scheduler: FCFS{}, wantTitle: "First-come, first-serve", wantOrder: []string{"P0", "P1", "P2"}},
+		{scheduler: SJF{}, wantTitle: "Shortest-job-first", wantOrder: []string{"P0", "P2", "P1"}},
+		{scheduler: SRTF{}, wantTitle: "Shortest-remaining-time-first", wantOrder: []string{"P0", "P1", "P2", "P1", "P0"}},
+		{scheduler: Priority{}, wantTitle: "Priority", wantOrder: []string{"P0", "P1", "P2"}},
+		{scheduler: Priority{Preemptive: true}, wantTitle: "Preemptive priority", wantOrder: []string{"P0", "P1", "P0", "P2"}},
+		{scheduler: RR{Quantum: 2}, wantTitle: "Round-robin", wantOrder: []string{"P0", "P1", "P2", "P0", "P1", "P0"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.wantTitle, func(t *testing.T) {
+			t.Parallel()
+			got := tt.scheduler.Schedule(processes)
+			if got.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", got.Title, tt.wantTitle)
+			}
+			var order []string
+			for _, slice := range got.Gantt {
+				order = append(order, slice.PID)
+			}
+			if diff := cmp.Diff(order, tt.wantOrder); diff != "" {
+				t.Errorf(diff)
+			}
+			if len(got.Rows) != len(processes) {
+				t.Errorf("got %d rows, want %d", len(got.Rows), len(processes))
+			}
+		})
+	}
+}
+
+func TestScheduler_invalid(t *testing.T) {
+	t.Parallel()
+	if got := (FCFS{}).Schedule([]Process{{ProcessID: "P0"}}); len(got.Rows) != 0 {
+		t.Errorf("FCFS scheduled a zero burst")
+	}
+	if got := (RR{}).Schedule([]Process{{ProcessID: "P0", BurstDuration: 1}}); len(got.Rows) != 0 {
+		t.Errorf("RR scheduled with no quantum")
+	}
+}
+
+// lastFirst runs processes in reverse input order, to have a Scheduler that is not built in.
+type lastFirst struct{}
+
+func (lastFirst) Schedule(processes []Process) ScheduleResult {
+	reversed := make([]Process, len(processes))
+	for i, p := range processes {
+		reversed[len(processes)-1-i] = p
+		reversed[len(processes)-1-i].ArrivalTime = 0
+	}
+	return FCFSSchedule(io.Discard, "Last first", reversed)
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+	Register("last-first", func(map[string]string) (Scheduler, error) { return lastFirst{}, nil })
+
+	scheduler, err := NewScheduler("last-first", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := scheduler.Schedule([]Process{{ProcessID: "A", BurstDuration: 1}, {ProcessID: "B", BurstDuration: 1}}); got.Gantt[0].PID != "B" {
+		t.Errorf("ran %s first, want B", got.Gantt[0].PID)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("registering the same name twice did not panic")
+		}
+	}()
+	Register("last-first", func(map[string]string) (Scheduler, error) { return lastFirst{}, nil })
+}
+
+func TestNewScheduler(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		params  map[string]string
+		want    Scheduler
+		wantErr string
+		wantIs  error
+	}{
+		{name: "fcfs", want: FCFS{}},
+		{name: "priority", want: Priority{}},
+		{name: "priority", params: map[string]string{"preemptive": "true"}, want: Priority{Preemptive: true}},
+		{name: "rr", params: map[string]string{"quantum": "3"}, want: RR{Quantum: 3}},
+		{
+			name:    "rr",
+			wantErr: "invalid scheduler parameters: rr requires a quantum",
+			wantIs:  ErrSchedulerParams,
+		},
+		{
+			name:    "rr",
+			params:  map[string]string{"quantum": "0"},
+			wantErr: `invalid scheduler parameters: rr quantum "0" must be an integer greater than 0`,
+			wantIs:  ErrSchedulerParams,
+		},
+		{
+			name:    "priority",
+			params:  map[string]string{"preemptive": "sometimes"},
+			wantErr: `invalid scheduler parameters: priority preemptive "sometimes" is not a boolean`,
+			wantIs:  ErrSchedulerParams,
+		},
+		{
+			name:    "lifo",
+			wantErr: `unknown scheduler: "lifo", must be one of: `,
+			wantIs:  ErrUnknownScheduler,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewScheduler(tt.name, tt.params)
+			if tt.wantErr != "" {
+				// The list of names grows as other tests register schedulers, so only the start is compared.
+				if !errors.Is(err, tt.wantIs) || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want prefix %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
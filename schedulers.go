@@ -1,9 +1,14 @@
 package main
 
 import (
+	"container/heap"
 	"fmt"
 	"io"
+	"math/rand"
+	"slices"
 	"sort"
+
+	"github.com/olekukonko/tablewriter"
 )
 
 type (
@@ -12,11 +17,81 @@ type (
 		ArrivalTime   int64
 		BurstDuration int64
 		Priority      int64
+		// Deadline is the absolute time a process should complete by, or 0 for no deadline.
+		Deadline int64
+		// NotBefore is the earliest time FCFS and SJF may start the process, even with the CPU free.
+		// Unlike a later arrival, waiting and turnaround are still counted from ArrivalTime. A NotBefore at or before
+		// ArrivalTime has no effect.
+		NotBefore int64
+		// Bursts is the process's alternating CPU and I/O segments, for FCFSIOSchedule.
+		// Without any, the process is a single CPU burst of BurstDuration. With some, BurstDuration must be their CPU total,
+		// so that the other schedulers can treat the process as CPU bound.
+		Bursts []BurstSegment
+		// GroupID gangs processes that must run at the same time, for GangSchedule. Empty means the process runs alone.
+		GroupID string
+		// Cgroup shares a CPU-time budget between processes, for BudgetSchedule. It is separate from GroupID,
+		// so processes can be ganged and budgeted differently. Empty means the process has no budget.
+		Cgroup string
 	}
 	TimeSlice struct {
-		PID   string
-		Start int64
-		Stop  int64
+		PID   string `json:"pid"`
+		Start int64  `json:"start"`
+		Stop  int64  `json:"stop"`
+		// Switch marks context-switch overhead rather than a process running.
+		Switch bool `json:"switch,omitempty"`
+		// Idle marks a block the gantt chart fills a gap with, where the CPU had nothing to run.
+		// Its PID is empty, so a process may be called "idle" too.
+		Idle bool `json:"idle,omitempty"`
+		// CPU is the index of the CPU the slice ran on, for schedules across more than one CPU.
+		CPU int `json:"cpu,omitempty"`
+		// Preempted marks a slice that ended because its quantum expired, with the process still left to run.
+		Preempted bool `json:"preempted,omitempty"`
+	}
+	// ScheduleRow is the computed timing of one process in a schedule.
+	ScheduleRow struct {
+		ProcessID     string `json:"processId"`
+		Priority      int64  `json:"priority"`
+		BurstDuration int64  `json:"burstDuration"`
+		ArrivalTime   int64  `json:"arrivalTime"`
+		Wait          int64  `json:"wait"`
+		Turnaround    int64  `json:"turnaround"`
+		Completion    int64  `json:"completion"`
+		Response      int64  `json:"response"`
+		// Blocked is the time the process spent on I/O, for schedulers that model it such as FCFSIOSchedule.
+		Blocked int64 `json:"blocked,omitempty"`
+	}
+	// ScheduleResult is everything a scheduler computes: the gantt slices, a row per process in input order, and the averages.
+	ScheduleResult struct {
+		Title         string        `json:"title"`
+		Gantt         []TimeSlice   `json:"gantt"`
+		Rows          []ScheduleRow `json:"rows"`
+		AveWait       float64       `json:"averageWait"`
+		AveTurnaround float64       `json:"averageTurnaround"`
+		AveResponse   float64       `json:"averageResponse"`
+		Throughput    float64       `json:"throughput"`
+		// Makespan is the latest completion of any process.
+		Makespan      int64   `json:"makespan"`
+		AveCompletion float64 `json:"averageCompletion"`
+		// DeadlineMisses counts processes that completed after their deadline.
+		DeadlineMisses int `json:"deadlineMisses"`
+		// CapPromotions counts the jobs SJF ran ahead of a shorter one because they had waited past WithWaitCap.
+		CapPromotions int `json:"capPromotions,omitempty"`
+		// FairShares is filled in by WeightedFairSchedule, in input order.
+		FairShares []FairShare `json:"fairShares,omitempty"`
+		// GangDelays is filled in by GangSchedule, in the order the groups started.
+		GangDelays []GangDelay `json:"gangDelays,omitempty"`
+		// GroupUsage is filled in by BudgetSchedule, sorted by Cgroup.
+		GroupUsage []GroupUsage `json:"groupUsage,omitempty"`
+	}
+	// FairShare compares the CPU time a process received with the time its weight entitled it to.
+	FairShare struct {
+		ProcessID string `json:"processId"`
+		Weight    int64  `json:"weight"`
+		// Actual is the CPU time the process ran for.
+		Actual int64 `json:"actual"`
+		// Fair is the CPU time an ideal proportional-share CPU would have given the process while it was ready:
+		// every unit of time is split between the ready processes in proportion to their weights.
+		Fair float64 `json:"fair"`
 	}
 )
 
@@ -26,40 +101,52 @@ type (
 // • an output writer
 // • a title for the chart
 // • a slice of processes
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
+// Processes run in the order they become eligible, on arrival or at their NotBefore if that is later,
+// and WithTieBreak orders those eligible together. The computed schedule is also returned.
+func FCFSSchedule(w io.Writer, title string, processes []Process, opts ...Option) ScheduleResult {
+	o := newOptions(opts)
+	if !o.schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := o.schedule(processes, func(processes []Process) ScheduleResult { return firstComeFirstServe(processes, o) })
+	result.Title = title
+	o.output(w, result)
+
+	return result
+}
+
+func firstComeFirstServe(processes []Process, o options) ScheduleResult {
 	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
+		serviceTime = max(o.warmUp, 0)
+		schedule    = make([]ScheduleRow, len(processes))
+		gantt       = make([]TimeSlice, 0)
 	)
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		totalWait += float64(waitingTime)
+	order := eligibleOrder(processes, o.tieBreak)
+	for _, i := range order {
+		// The CPU sits idle until the process is eligible if it has nothing else to run.
+		start := max(serviceTime, eligibleAt(processes[i]))
+
+		waitingTime := start - processes[i].ArrivalTime
 
-		start := waitingTime + processes[i].ArrivalTime
+		response := start - processes[i].ArrivalTime
 
 		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
 
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
+		completion := processes[i].BurstDuration + start
 
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
+		schedule[i] = ScheduleRow{
+			ProcessID:     processes[i].ProcessID,
+			Priority:      processes[i].Priority,
+			BurstDuration: processes[i].BurstDuration,
+			ArrivalTime:   processes[i].ArrivalTime,
+			Wait:          waitingTime,
+			Turnaround:    turnaround,
+			Completion:    completion,
+			Response:      response,
 		}
-		serviceTime += processes[i].BurstDuration
+		serviceTime = completion
 
 		gantt = append(gantt, TimeSlice{
 			PID:   processes[i].ProcessID,
@@ -68,67 +155,168 @@ func FCFSSchedule(w io.Writer, title string, processes []Process) {
 		})
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	return newScheduleResult(gantt, schedule)
+}
+
+// FCFSScheduleMulti outputs and returns a first-come, first-serve schedule across cpus CPUs.
+// Processes are taken in arrival order, ties in input order, and each goes to the CPU that becomes free earliest,
+// the lowest numbered CPU on ties. The gantt chart has a row per CPU.
+func FCFSScheduleMulti(w io.Writer, title string, processes []Process, cpus int) ScheduleResult {
+	if cpus < 1 {
+		_, _ = fmt.Fprintf(w, "invalid CPU count %d: must be at least 1\n", cpus)
+		return ScheduleResult{Title: title}
+	}
+	if !schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := firstComeFirstServeMulti(processes, cpus)
+	result.Title = title
+	outputResult(w, result)
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return result
 }
 
-func SJFSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-	remaining := make([]Process, len(processes))
-	copy(remaining, processes)
+func firstComeFirstServeMulti(processes []Process, cpus int) ScheduleResult {
+	schedule := make([]ScheduleRow, len(processes))
+	gantt := make([]TimeSlice, 0)
+	free := make([]int64, cpus)
+
+	order := arrivalOrder(processes, TieDefault)
+
+	for _, i := range order {
+		cpu := 0
+		for c := range free {
+			if free[c] < free[cpu] {
+				cpu = c
+			}
+		}
 
-	byArrivalTime := func(p1, p2 *Process) bool {
-		return p1.ArrivalTime < p2.ArrivalTime
+		start := free[cpu]
+		if processes[i].ArrivalTime > start {
+			start = processes[i].ArrivalTime
+		}
+		completion := start + processes[i].BurstDuration
+		free[cpu] = completion
+
+		schedule[i] = ScheduleRow{
+			ProcessID:     processes[i].ProcessID,
+			Priority:      processes[i].Priority,
+			BurstDuration: processes[i].BurstDuration,
+			ArrivalTime:   processes[i].ArrivalTime,
+			Wait:          start - processes[i].ArrivalTime,
+			Turnaround:    completion - processes[i].ArrivalTime,
+			Completion:    completion,
+			Response:      start - processes[i].ArrivalTime,
+		}
+		gantt = append(gantt, TimeSlice{
+			PID:   processes[i].ProcessID,
+			Start: start,
+			Stop:  completion,
+			CPU:   cpu,
+		})
+	}
+
+	return newScheduleResult(gantt, schedule)
+}
+
+// SJFSchedule outputs and returns a non-preemptive shortest-job-first schedule.
+// Equal bursts go to the smaller ProcessID, or as WithTieBreak says. A process is not considered before its NotBefore.
+// WithLookahead makes it an offline scheduler that may leave the CPU idle for a shorter job about to arrive.
+// With WithWaitCap, how many jobs the cap promoted is reported.
+func SJFSchedule(w io.Writer, title string, processes []Process, opts ...Option) ScheduleResult {
+	o := newOptions(opts)
+	if !o.schedulable(w, processes) {
+		return ScheduleResult{Title: title}
 	}
+	processes = cloneProcesses(processes)
 
-	sort.SliceStable(remaining, byArrivalTime)
+	result := o.schedule(processes, func(processes []Process) ScheduleResult { return shortestJobFirst(processes, o) })
+	result.Title = title
+	o.output(w, result)
+	if o.waitCap > 0 && !o.quiet {
+		_, _ = fmt.Fprintf(w, "Cap promotions: %d\n", result.CapPromotions)
+	}
+
+	return result
+}
+
+func shortestJobFirst(processes []Process, o options) ScheduleResult {
+	var (
+		serviceTime = max(o.warmUp, 0)
+		schedule    = make([]ScheduleRow, len(processes))
+		gantt       = make([]TimeSlice, 0)
+	)
+	order := eligibleOrder(processes, TieDefault)
 
-	for len(remaining) > 0 {
-		next := findShortestJob(remaining, serviceTime)
-		if next == nil {
-			// No available jobs
-			serviceTime++
+	// Eligible jobs wait in a heap, so the shortest is always on top.
+	waiting := jobHeap{processes: processes, tie: o.tieBreak}
+	ran := make([]bool, len(processes))
+	arrived, promotions := 0, 0
+	for arrived < len(order) || waiting.Len() > 0 {
+		for arrived < len(order) && eligibleAt(processes[order[arrived]]) <= serviceTime {
+			// A job lookahead ran early is already done by the time it arrives.
+			if !ran[order[arrived]] {
+				heap.Push(&waiting, order[arrived])
+			}
+			arrived++
+		}
+		if waiting.Len() == 0 {
+			if arrived == len(order) {
+				break
+			}
+			// No available jobs, jump to the next one to become eligible.
+			serviceTime = eligibleAt(processes[order[arrived]])
 			continue
 		}
 
-		process := *next
-		remaining = removeProcess(remaining, process)
+		// Table rows follow the input order, whatever order the jobs run in.
+		i := heap.Pop(&waiting).(int)
+		if j := overCap(processes, waiting.jobs, i, serviceTime, o.waitCap); j != i {
+			heap.Remove(&waiting, slices.Index(waiting.jobs, j))
+			heap.Push(&waiting, i)
+			i = j
+			promotions++
+		} else if o.lookahead {
+			if j := sjfLookahead(processes, ran, i, serviceTime, o.tieBreak); j != i {
+				heap.Push(&waiting, i)
+				i = j
+				serviceTime = eligibleAt(processes[j])
+			}
+		}
+		ran[i] = true
+		process := processes[i]
+		if o.trace != nil {
+			others := append([]int(nil), waiting.jobs...)
+			sort.Ints(others)
+			traceSelection(o.trace, serviceTime, processes, i, others, func(j int) string {
+				return fmt.Sprintf("burst=%d", processes[j].BurstDuration)
+			})
+		}
 
 		waitingTime := serviceTime - process.ArrivalTime
 		if waitingTime < 0 {
 			waitingTime = 0
 		}
-		totalWait += float64(waitingTime)
 
 		start := serviceTime
 
+		response := start - process.ArrivalTime
+
 		turnaround := process.BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
 
 		completion := process.BurstDuration + serviceTime
-		lastCompletion = float64(completion)
 
-		schedule[process.ProcessID-1] = []string{
-			fmt.Sprint(process.ProcessID),
-			fmt.Sprint(process.Priority),
-			fmt.Sprint(process.BurstDuration),
-			fmt.Sprint(process.ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
+		schedule[i] = ScheduleRow{
+			ProcessID:     process.ProcessID,
+			Priority:      process.Priority,
+			BurstDuration: process.BurstDuration,
+			ArrivalTime:   process.ArrivalTime,
+			Wait:          waitingTime,
+			Turnaround:    turnaround,
+			Completion:    completion,
+			Response:      response,
 		}
 
 		gantt = append(gantt, TimeSlice{
@@ -140,94 +328,1090 @@ func SJFSchedule(w io.Writer, title string, processes []Process) {
 		serviceTime += process.BurstDuration
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	result := newScheduleResult(gantt, schedule)
+	result.CapPromotions = promotions
+
+	return result
+}
+
+// overCap returns the job that has waited longest past waitCap at serviceTime, out of shortest and the rest of waiting,
+// or shortest if none has or waitCap is not greater than 0. Jobs that have waited equally long go in input order.
+func overCap(processes []Process, waiting []int, shortest int, serviceTime, waitCap int64) int {
+	if waitCap <= 0 {
+		return shortest
+	}
+	promoted, longest := shortest, waitCap
+	for _, i := range append([]int{shortest}, waiting...) {
+		waited := serviceTime - processes[i].ArrivalTime
+		if waited > longest || waited == longest && waited > waitCap && i < promoted {
+			promoted, longest = i, waited
+		}
+	}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return promoted
 }
 
-func findShortestJob(remaining []Process, serviceTime int64) *Process {
-	var shortest *Process
-	for i := range remaining {
-		if remaining[i].ArrivalTime > serviceTime {
-			break
+// sjfLookahead picks the job to run at serviceTime in place of shortest, the shortest ready job.
+// The candidates are shortest and any shorter job becoming eligible before shortest would finish. Each is scored by
+// the total wait of every job not yet run if it went next, idling until it is eligible, and online SJF took over after.
+// The lowest score wins, and shortest keeps ties, so the CPU only idles when that strictly cuts the total wait.
+func sjfLookahead(processes []Process, ran []bool, shortest int, serviceTime int64, tie TieBreak) int {
+	score := func(i int) int64 {
+		start := max(serviceTime, eligibleAt(processes[i]))
+		done := append([]bool(nil), ran...)
+		done[i] = true
+		return start - processes[i].ArrivalTime + sjfTotalWait(processes, done, start+processes[i].BurstDuration, tie)
+	}
+
+	best, bestScore := shortest, score(shortest)
+	finish := serviceTime + processes[shortest].BurstDuration
+	for j, p := range processes {
+		if ran[j] || eligibleAt(p) <= serviceTime || eligibleAt(p) >= finish ||
+			p.BurstDuration >= processes[shortest].BurstDuration {
+			continue
 		}
-		if shortest == nil || remaining[i].BurstDuration < shortest.BurstDuration {
-			shortest = &remaining[i]
+		if s := score(j); s < bestScore {
+			best, bestScore = j, s
 		}
 	}
-	return shortest
+
+	return best
 }
 
-func removeProcess(processes []Process, process Process) []Process {
-	var remaining []Process
-	for i := range processes {
-		if processes[i].ProcessID != process.ProcessID {
-			remaining = append(remaining, processes[i])
+// sjfTotalWait is the total wait of the jobs not done if online SJF runs them from serviceTime.
+func sjfTotalWait(processes []Process, done []bool, serviceTime int64, tie TieBreak) int64 {
+	var total int64
+	ready := jobHeap{processes: processes, tie: tie}
+	for {
+		ready.jobs = ready.jobs[:0]
+		next := int64(-1)
+		for i, p := range processes {
+			switch {
+			case done[i]:
+			case eligibleAt(p) <= serviceTime:
+				ready.jobs = append(ready.jobs, i)
+			case next < 0 || eligibleAt(p) < next:
+				next = eligibleAt(p)
+			}
+		}
+		if len(ready.jobs) == 0 {
+			if next < 0 {
+				return total
+			}
+			serviceTime = next
+			continue
+		}
+
+		shortest := 0
+		for k := range ready.jobs {
+			if ready.Less(k, shortest) {
+				shortest = k
+			}
 		}
+		i := ready.jobs[shortest]
+		done[i] = true
+		total += serviceTime - processes[i].ArrivalTime
+		serviceTime += processes[i].BurstDuration
+	}
+}
+
+// jobHeap is a container/heap of the indexes of arrived jobs, with the shortest burst on top.
+// Equal bursts go to the lexicographically smaller ProcessID unless tie says otherwise,
+// so by default the choice never depends on input order.
+type jobHeap struct {
+	processes []Process
+	jobs      []int
+	tie       TieBreak
+}
+
+func (h jobHeap) Len() int { return len(h.jobs) }
+
+func (h jobHeap) Less(i, j int) bool {
+	a, b := h.jobs[i], h.jobs[j]
+	if h.processes[a].BurstDuration != h.processes[b].BurstDuration {
+		return h.processes[a].BurstDuration < h.processes[b].BurstDuration
+	}
+	if h.tie == TieDefault {
+		return tieLess(TieByID, h.processes, a, b)
 	}
-	return remaining
+	return tieLess(h.tie, h.processes, a, b)
+}
+
+func (h jobHeap) Swap(i, j int) { h.jobs[i], h.jobs[j] = h.jobs[j], h.jobs[i] }
+
+func (h *jobHeap) Push(x any) { h.jobs = append(h.jobs, x.(int)) }
+
+func (h *jobHeap) Pop() any {
+	last := h.jobs[len(h.jobs)-1]
+	h.jobs = h.jobs[:len(h.jobs)-1]
+	return last
 }
 
-type Process1 struct {
-	Name       string
-	Burst      int
-	Arrival    int
-	Priority   int
+// sjfpProcess is the per-run scheduling state SJFPrioritySchedule keeps for a process,
+// so the caller's processes are never modified.
+type sjfpProcess struct {
+	Process
+	// burst is the original BurstDuration, which counts down to zero as the process runs.
+	burst      int64
 	Completed  bool
-	Turnaround int
-	Waiting    int
+	Turnaround int64
+	Waiting    int64
+	// FirstStart is the time the process first ran.
+	FirstStart int64
+}
+
+// SJFPrioritySchedule outputs and returns a shortest-job-first schedule simulated one time unit at a time.
+// Of opts, only those for the output apply.
+func SJFPrioritySchedule(w io.Writer, title string, processes []Process, opts ...Option) ScheduleResult {
+	if !schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := sjfPriority(processes)
+	result.Title = title
+	newOptions(opts).output(w, result)
+
+	return result
 }
 
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process1) {
-	fmt.Fprintf(w, "------ %s ------\n", title)
+func sjfPriority(processes []Process) ScheduleResult {
+	work := make([]sjfpProcess, len(processes))
+	for i := range processes {
+		work[i].Process = processes[i]
+		work[i].burst = processes[i].BurstDuration
+	}
 
 	completed := 0
-	currentTime := 0
-	var waiting []Process1
-	var active *Process1
+	var currentTime int64
+	active := -1
+	gantt := make([]TimeSlice, 0)
 
-	for completed < len(processes) {
-		for i := range processes {
-			if !processes[i].Completed && processes[i].Arrival <= currentTime {
-				waiting = append(waiting, processes[i])
+	for completed < len(work) {
+		// The ready queue is rebuilt every tick from the arrived processes that are neither done nor running,
+		// so each process is in it at most once.
+		var waiting []int
+		for i := range work {
+			if !work[i].Completed && i != active && work[i].ArrivalTime <= currentTime {
+				waiting = append(waiting, i)
 			}
 		}
-		sort.Slice(waiting, func(i, j int) bool {
-			return waiting[i].Burst < waiting[j].Burst
+		sort.SliceStable(waiting, func(i, j int) bool {
+			return work[waiting[i]].BurstDuration < work[waiting[j]].BurstDuration
 		})
-		if active == nil && len(waiting) > 0 {
-			active = &waiting[0]
-			waiting = waiting[1:]
-		}
-		if active != nil {
-			active.Burst--
-			if active.Burst == 0 {
-				active.Completed = true
+		if active < 0 && len(waiting) > 0 {
+			active = waiting[0]
+			work[active].FirstStart = currentTime
+		}
+		if active >= 0 {
+			p := &work[active]
+			// Consecutive ticks of the same process are one slice.
+			if last := len(gantt) - 1; last >= 0 && gantt[last].PID == p.ProcessID && gantt[last].Stop == currentTime {
+				gantt[last].Stop++
+			} else {
+				gantt = append(gantt, TimeSlice{PID: p.ProcessID, Start: currentTime, Stop: currentTime + 1})
+			}
+			p.BurstDuration--
+			if p.BurstDuration == 0 {
+				p.Completed = true
 				completed++
-				active.Turnaround = currentTime + 1 - active.Arrival
-				active.Waiting = active.Turnaround - active.Priority
-				active = nil
+				p.Turnaround = currentTime + 1 - p.ArrivalTime
+				p.Waiting = p.Turnaround - p.burst
+				active = -1
 			}
 		}
 		currentTime++
 	}
 
-	var totalTurnaround, totalWaiting int
+	schedule := make([]ScheduleRow, len(work))
+	for i, p := range work {
+		schedule[i] = ScheduleRow{
+			ProcessID:     p.ProcessID,
+			Priority:      p.Priority,
+			BurstDuration: p.burst,
+			ArrivalTime:   p.ArrivalTime,
+			Wait:          p.Waiting,
+			Turnaround:    p.Turnaround,
+			Completion:    p.ArrivalTime + p.Turnaround,
+			Response:      p.FirstStart - p.ArrivalTime,
+		}
+	}
+
+	return newScheduleResult(gantt, schedule)
+}
+
+// PrioritySchedule outputs and returns a priority schedule.
+// A lower Priority value means a higher priority, so a process with priority 1 runs before one with priority 2,
+// unless WithPriorityOrder(AscendingIsHigher) turns that around.
+// Processes with equal priority run in order of arrival, falling back to input order or WithTieBreak.
+// Without preemption a process runs to completion once picked, so the choice is only made when the CPU frees up.
+// With preemption an arrival with a higher priority immediately preempts the running process,
+// and WithAging can stop a stream of high-priority arrivals starving lower priorities.
+func PrioritySchedule(w io.Writer, title string, processes []Process, preemptive bool, opts ...Option) ScheduleResult {
+	o := newOptions(opts)
+	if !o.schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := o.schedule(processes, func(processes []Process) ScheduleResult {
+		if preemptive {
+			return preemptivePriority(processes, o)
+		}
+		return highestPriority(processes, o)
+	})
+	result.Title = title
+	o.output(w, result)
+
+	return result
+}
+
+// highestPriority runs the arrived process with the highest priority to completion each time the CPU frees up.
+func highestPriority(processes []Process, o options) ScheduleResult {
+	return nonPreemptive(processes, o, func(_ int64, i, j int) bool {
+		return o.outranks(processes[i].Priority, processes[j].Priority)
+	}, func(_ int64, i int) string {
+		return fmt.Sprintf("priority=%d", processes[i].Priority)
+	})
+}
+
+// HRRNSchedule outputs and returns a highest-response-ratio-next schedule.
+// Each time the CPU frees up, the arrived process with the highest (waiting time + burst) / burst runs to completion,
+// so short jobs are favored but long jobs gain ground the longer they wait.
+func HRRNSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	if !schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := highestResponseRatio(processes)
+	result.Title = title
+	outputResult(w, result)
+
+	return result
+}
+
+func highestResponseRatio(processes []Process) ScheduleResult {
+	return nonPreemptive(processes, options{}, func(serviceTime int64, i, j int) bool {
+		// Compare (wi + bi) / bi > (wj + bj) / bj without dividing.
+		wi, bi := serviceTime-processes[i].ArrivalTime, processes[i].BurstDuration
+		wj, bj := serviceTime-processes[j].ArrivalTime, processes[j].BurstDuration
+		return (wi+bi)*bj > (wj+bj)*bi
+	}, func(serviceTime int64, i int) string {
+		wi, bi := serviceTime-processes[i].ArrivalTime, processes[i].BurstDuration
+		return fmt.Sprintf("ratio=%.2f", float64(wi+bi)/float64(bi))
+	})
+}
+
+// nonPreemptive runs the arrived process that sorts first by less to completion each time the CPU frees up.
+// less is given the current service time and two process indexes, and key describes what a process is ranked by for WithTrace.
+// Arrived processes are considered in arrival order, so only a strictly lesser process displaces an earlier arrival,
+// and simultaneous arrivals are ordered by the tie break of o.
+func nonPreemptive(processes []Process, o options, less func(serviceTime int64, i, j int) bool, key func(serviceTime int64, i int) string) ScheduleResult {
+	var serviceTime int64
+	schedule := make([]ScheduleRow, len(processes))
+	gantt := make([]TimeSlice, 0)
+
+	order := arrivalOrder(processes, o.tieBreak)
+
+	completed := make([]bool, len(processes))
+	for done := 0; done < len(processes); {
+		next := -1
+		for _, i := range order {
+			if processes[i].ArrivalTime > serviceTime {
+				break
+			}
+			if completed[i] {
+				continue
+			}
+			if next == -1 || less(serviceTime, i, next) {
+				next = i
+			}
+		}
+		if next == -1 {
+			// No available jobs, jump to the next arrival.
+			for _, i := range order {
+				if processes[i].ArrivalTime > serviceTime {
+					serviceTime = processes[i].ArrivalTime
+					break
+				}
+			}
+			continue
+		}
+		if o.trace != nil {
+			var others []int
+			for _, i := range order {
+				if processes[i].ArrivalTime <= serviceTime && !completed[i] && i != next {
+					others = append(others, i)
+				}
+			}
+			traceSelection(o.trace, serviceTime, processes, next, others, func(i int) string { return key(serviceTime, i) })
+		}
+		completed[next] = true
+		done++
+
+		process := processes[next]
+
+		waitingTime := serviceTime - process.ArrivalTime
+
+		start := serviceTime
+
+		response := start - process.ArrivalTime
+
+		turnaround := process.BurstDuration + waitingTime
+
+		completion := process.BurstDuration + serviceTime
+
+		schedule[next] = ScheduleRow{
+			ProcessID:     process.ProcessID,
+			Priority:      process.Priority,
+			BurstDuration: process.BurstDuration,
+			ArrivalTime:   process.ArrivalTime,
+			Wait:          waitingTime,
+			Turnaround:    turnaround,
+			Completion:    completion,
+			Response:      response,
+		}
+
+		gantt = append(gantt, TimeSlice{
+			PID:   process.ProcessID,
+			Start: start,
+			Stop:  completion,
+		})
+
+		serviceTime += process.BurstDuration
+	}
+
+	return newScheduleResult(gantt, schedule)
+}
+
+// SRTFSchedule outputs a preemptive shortest-job-first (shortest remaining time first) schedule.
+// The running process is preempted whenever an arrival has a strictly shorter remaining burst.
+// On a tie in remaining time the running process keeps the CPU, so equal bursts never cause a context switch.
+func SRTFSchedule(w io.Writer, title string, processes []Process, opts ...Option) ScheduleResult {
+	o := newOptions(opts)
+	if !o.schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := o.schedule(processes, func(processes []Process) ScheduleResult { return shortestRemainingTime(processes, o) })
+	result.Title = title
+	o.output(w, result)
+
+	return result
+}
+
+// shortestRemainingTime always runs the arrived process with the least remaining burst.
+func shortestRemainingTime(processes []Process, o options) ScheduleResult {
+	return preemptive(processes, o, func(remaining, _ []int64, i, j int) bool {
+		return remaining[i] < remaining[j]
+	}, func(remaining, _ []int64, i int) string {
+		return fmt.Sprintf("remaining=%d", remaining[i])
+	})
+}
+
+// preemptivePriority always runs the arrived process with the highest priority.
+// With aging, a ready process's effective priority improves by one for every agingInterval units it has waited
+// since it arrived or last ran.
+func preemptivePriority(processes []Process, o options) ScheduleResult {
+	effective := func(waited []int64, i int) int64 {
+		if o.agingInterval <= 0 {
+			return processes[i].Priority
+		}
+		boost := waited[i] / o.agingInterval
+		if o.priorityOrder == AscendingIsHigher {
+			return processes[i].Priority + boost
+		}
+		return processes[i].Priority - boost
+	}
+	return preemptive(processes, o, func(_, waited []int64, i, j int) bool {
+		return o.outranks(effective(waited, i), effective(waited, j))
+	}, func(_, waited []int64, i int) string {
+		return fmt.Sprintf("priority=%d", effective(waited, i))
+	})
+}
+
+// EDFSchedule outputs and returns a preemptive earliest-deadline-first schedule.
+// The arrived process with the nearest Deadline runs, and processes without a deadline run only when no other process is ready.
+// The number of processes completing after their deadline is reported, then the lateness of each process with a deadline
+// and the maximum lateness, which EDF makes as small as any schedule can.
+func EDFSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	if !schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := earliestDeadline(processes)
+	result.Title = title
+	outputResult(w, result)
+	_, _ = fmt.Fprintf(w, "Deadline misses: %d\n", result.DeadlineMisses)
+	outputLateness(w, processes, result)
+
+	return result
+}
+
+// outputLateness writes the completion and lateness of each process with a deadline, in input order,
+// then the maximum lateness. It writes nothing if no process has a deadline.
+func outputLateness(w io.Writer, processes []Process, result ScheduleResult) {
+	latest, ok := MaxLateness(processes, result)
+	if !ok {
+		return
+	}
+	lateness := Lateness(processes, result)
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Lateness")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Deadline", "Exit", "Lateness"})
+	for i, p := range processes {
+		if p.Deadline > 0 {
+			table.Append([]string{p.ProcessID, fmt.Sprint(p.Deadline), fmt.Sprint(result.Rows[i].Completion), fmt.Sprint(lateness[p.ProcessID])})
+		}
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Max lateness: %d\n", latest)
+}
+
+func earliestDeadline(processes []Process) ScheduleResult {
+	result := preemptive(processes, options{}, func(_, _ []int64, i, j int) bool {
+		di, dj := processes[i].Deadline, processes[j].Deadline
+		if di == 0 || dj == 0 {
+			return di != 0 && dj == 0
+		}
+		return di < dj
+	}, func(_, _ []int64, i int) string {
+		return fmt.Sprintf("deadline=%d", processes[i].Deadline)
+	})
 	for i := range processes {
-		totalTurnaround += processes[i].Turnaround
-		totalWaiting += processes[i].Waiting
+		if processes[i].Deadline > 0 && result.Rows[i].Completion > processes[i].Deadline {
+			result.DeadlineMisses++
+		}
 	}
 
-	fmt.Fprintf(w, "Average turnaround time: %.2f\n", float64(totalTurnaround)/float64(len(processes)))
-	fmt.Fprintf(w, "Average waiting time: %.2f\n", float64(totalWaiting)/float64(len(processes)))
-	fmt.Fprintf(w, "Throughput: %.2f\n", float64(len(processes))/float64(currentTime))
+	return result
 }
 
-func RRSchedule(w io.Writer, title string, processes []Process) {}
+// preemptive always runs the arrived process that sorts first by less, which is given the remaining bursts,
+// how long each ready process has waited since it arrived or last ran, and two process indexes.
+// key describes what a process is ranked by for WithTrace, which is written to whenever a different process is dispatched.
+// Only a strictly lesser process preempts the running one, so on a tie the running process keeps the CPU.
+// Otherwise ready processes are considered in arrival order, so only a strictly lesser process displaces an earlier arrival.
+// Time jumps from event to event (an arrival or the running process completing),
+// since the choice of process can only change at those points.
+// Arrivals during a context switch are only considered once the switched-in process reaches its next event.
+// With aging, each point where a waiting process is due a boost is an event too.
+func preemptive(processes []Process, o options, less func(remaining, waited []int64, i, j int) bool, key func(remaining, waited []int64, i int) string) ScheduleResult {
+	var serviceTime int64
+	schedule := make([]ScheduleRow, len(processes))
+	gantt := make([]TimeSlice, 0)
+
+	order := arrivalOrder(processes, o.tieBreak)
+
+	remaining := make([]int64, len(processes))
+	firstStart := make([]int64, len(processes))
+	readySince := make([]int64, len(processes))
+	waited := make([]int64, len(processes))
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+		firstStart[i] = -1
+		readySince[i] = processes[i].ArrivalTime
+	}
+
+	var (
+		ready   []int
+		arrived int
+		running = -1
+	)
+	admitArrivals := func() {
+		for arrived < len(order) && processes[order[arrived]].ArrivalTime <= serviceTime {
+			ready = append(ready, order[arrived])
+			arrived++
+		}
+	}
+	for done := 0; done < len(processes); {
+		admitArrivals()
+		if len(ready) == 0 {
+			// No available jobs, jump to the next arrival.
+			serviceTime = processes[order[arrived]].ArrivalTime
+			continue
+		}
+
+		for _, r := range ready {
+			waited[r] = serviceTime - readySince[r]
+		}
+		// Start from the running process if it is still ready, so a tie never preempts it.
+		next := 0
+		for r := range ready {
+			if ready[r] == running {
+				next = r
+			}
+		}
+		for r := range ready {
+			if less(remaining, waited, ready[r], ready[next]) {
+				next = r
+			}
+		}
+		i := ready[next]
+		if o.trace != nil && i != running {
+			others := make([]int, 0, len(ready)-1)
+			for _, r := range ready {
+				if r != i {
+					others = append(others, r)
+				}
+			}
+			traceSelection(o.trace, serviceTime, processes, i, others, func(j int) string { return key(remaining, waited, j) })
+		}
+		running = i
+
+		gantt, serviceTime = contextSwitch(gantt, processes[i].ProcessID, serviceTime, o.switchCost)
+		admitArrivals()
+
+		// Run until the process completes or the next arrival may preempt it.
+		run := remaining[i]
+		if arrived < len(order) {
+			if untilArrival := processes[order[arrived]].ArrivalTime - serviceTime; untilArrival < run {
+				run = untilArrival
+			}
+		}
+		if o.agingInterval > 0 {
+			for _, j := range ready {
+				if j == i {
+					continue
+				}
+				// The next boost is due at the next multiple of the interval since j became ready.
+				boostAt := readySince[j] + ((serviceTime-readySince[j])/o.agingInterval+1)*o.agingInterval
+				if untilBoost := boostAt - serviceTime; untilBoost < run {
+					run = untilBoost
+				}
+			}
+		}
+		start := serviceTime
+		serviceTime += run
+		remaining[i] -= run
+		readySince[i] = serviceTime
+		if firstStart[i] < 0 {
+			firstStart[i] = start
+		}
+
+		if last := len(gantt) - 1; last >= 0 && gantt[last].PID == processes[i].ProcessID && gantt[last].Stop == start {
+			gantt[last].Stop = serviceTime
+		} else {
+			gantt = append(gantt, TimeSlice{
+				PID:   processes[i].ProcessID,
+				Start: start,
+				Stop:  serviceTime,
+			})
+		}
+
+		if remaining[i] > 0 {
+			continue
+		}
+		ready = append(ready[:next], ready[next+1:]...)
+		done++
+
+		completion := serviceTime
+
+		turnaround := completion - processes[i].ArrivalTime
+
+		waitingTime := turnaround - processes[i].BurstDuration
+
+		response := firstStart[i] - processes[i].ArrivalTime
+
+		schedule[i] = ScheduleRow{
+			ProcessID:     processes[i].ProcessID,
+			Priority:      processes[i].Priority,
+			BurstDuration: processes[i].BurstDuration,
+			ArrivalTime:   processes[i].ArrivalTime,
+			Wait:          waitingTime,
+			Turnaround:    turnaround,
+			Completion:    completion,
+			Response:      response,
+		}
+	}
+
+	return newScheduleResult(gantt, schedule)
+}
+
+// RRSchedule outputs a round-robin schedule of processes given:
+// • an output writer
+// • a title for the chart
+// • a time quantum, which must be greater than 0
+// • a slice of processes
+// WithQuanta replaces the single quantum with one per round, and quantum is then ignored.
+func RRSchedule(w io.Writer, title string, quantum int64, processes []Process, opts ...Option) ScheduleResult {
+	o := newOptions(opts)
+	for _, q := range roundQuanta(quantum, o) {
+		if q <= 0 {
+			_, _ = fmt.Fprintf(w, "invalid time quantum %d: must be greater than 0\n", q)
+			return ScheduleResult{Title: title}
+		}
+	}
+	if !o.schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := o.schedule(processes, func(processes []Process) ScheduleResult { return roundRobin(processes, quantum, o) })
+	result.Title = title
+	o.output(w, result)
+
+	return result
+}
+
+// roundQuanta is the quantum of each round, cycled through: the WithQuanta quanta if there are any, otherwise just quantum.
+func roundQuanta(quantum int64, o options) []int64 {
+	if len(o.quanta) > 0 {
+		return o.quanta
+	}
+	return []int64{quantum}
+}
+
+// roundRobin runs processes in arrival order, preempting each after quantum units, or the round's quantum
+// from WithQuanta, and re-queueing it at the tail.
+// A process that is re-dispatched because nothing else was waiting extends its previous slice.
+//
+// The ready queue is ordered the same way on every run: when a quantum expires, every process that arrived
+// up to and including that instant is queued first, those arriving together in input order, and only then
+// is the preempted process re-queued behind them. With a quantum of 2, P0 arriving at 0 and
+// P3, P1, P2 (listed in that order) all arriving at 2:
+//
+//	time 2: P0 preempted, queue [P3 P1 P2] then P0
+//	|  P0  |  P3  |  P1  |  P2  |  P0  |
+//	0      2      3      4      5      7
+func roundRobin(processes []Process, quantum int64, o options) ScheduleResult {
+	sim := newRRSimulation(processes, roundQuanta(quantum, o), o.switchCost)
+	for sim.Step() {
+	}
+
+	return sim.Result()
+}
+
+// MLFQSchedule outputs and returns a multilevel feedback queue schedule, with one queue per entry in quanta.
+// The first queue has the highest priority, and a process runs from the highest non-empty queue:
+// • new processes enter the first queue
+// • a process that uses its whole quantum at level i is demoted to level i+1
+// • the last queue is round-robin with the last quantum, so a process that keeps using its quantum stays there
+// • an arrival preempts a process running from a lower queue, which goes back to the tail of its own queue without being demoted
+// Since processes never block for I/O, only running out of burst ends a quantum early.
+func MLFQSchedule(w io.Writer, title string, processes []Process, quanta []int64) ScheduleResult {
+	if len(quanta) == 0 {
+		_, _ = fmt.Fprintln(w, "at least one queue quantum is required")
+		return ScheduleResult{Title: title}
+	}
+	for _, quantum := range quanta {
+		if quantum <= 0 {
+			_, _ = fmt.Fprintf(w, "invalid time quantum %d: must be greater than 0\n", quantum)
+			return ScheduleResult{Title: title}
+		}
+	}
+	if !schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := multilevelFeedback(processes, quanta)
+	result.Title = title
+	outputResult(w, result)
+
+	return result
+}
+
+func multilevelFeedback(processes []Process, quanta []int64) ScheduleResult {
+	var serviceTime int64
+	schedule := make([]ScheduleRow, len(processes))
+	gantt := make([]TimeSlice, 0)
+
+	order := arrivalOrder(processes, TieDefault)
+
+	remaining := make([]int64, len(processes))
+	firstStart := make([]int64, len(processes))
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+		firstStart[i] = -1
+	}
+
+	var (
+		queues  = make([][]int, len(quanta))
+		arrived int
+	)
+	enqueueArrivals := func() {
+		for arrived < len(order) && processes[order[arrived]].ArrivalTime <= serviceTime {
+			queues[0] = append(queues[0], order[arrived])
+			arrived++
+		}
+	}
+
+	for done := 0; done < len(processes); {
+		enqueueArrivals()
+		level := 0
+		for level < len(queues) && len(queues[level]) == 0 {
+			level++
+		}
+		if level == len(queues) {
+			// No available jobs, jump to the next arrival.
+			serviceTime = processes[order[arrived]].ArrivalTime
+			continue
+		}
+
+		i := queues[level][0]
+		queues[level] = queues[level][1:]
+
+		quantum := quanta[level]
+		run := quantum
+		if remaining[i] < run {
+			run = remaining[i]
+		}
+		// Below the first queue, the next arrival preempts.
+		if level > 0 && arrived < len(order) {
+			if untilArrival := processes[order[arrived]].ArrivalTime - serviceTime; untilArrival < run {
+				run = untilArrival
+			}
+		}
+		start := serviceTime
+		serviceTime += run
+		remaining[i] -= run
+		if firstStart[i] < 0 {
+			firstStart[i] = start
+		}
+
+		gantt = append(gantt, TimeSlice{
+			PID:   processes[i].ProcessID,
+			Start: start,
+			Stop:  serviceTime,
+		})
+
+		enqueueArrivals()
+		if remaining[i] > 0 {
+			if run == quantum && level < len(queues)-1 {
+				level++
+			}
+			queues[level] = append(queues[level], i)
+			continue
+		}
+		done++
+
+		completion := serviceTime
+
+		turnaround := completion - processes[i].ArrivalTime
+
+		waitingTime := turnaround - processes[i].BurstDuration
+
+		response := firstStart[i] - processes[i].ArrivalTime
+
+		schedule[i] = ScheduleRow{
+			ProcessID:     processes[i].ProcessID,
+			Priority:      processes[i].Priority,
+			BurstDuration: processes[i].BurstDuration,
+			ArrivalTime:   processes[i].ArrivalTime,
+			Wait:          waitingTime,
+			Turnaround:    turnaround,
+			Completion:    completion,
+			Response:      response,
+		}
+	}
+
+	return newScheduleResult(gantt, schedule)
+}
+
+// lotteryMaxTickets is one more than the lowest priority (50), so priority 1 holds 50 tickets and priority 50 holds 1.
+const lotteryMaxTickets = 51
+
+// LotterySchedule outputs and returns a lottery schedule: every time unit, a ticket is drawn among the arrived processes
+// and its holder runs for that unit. A process holds 51 - Priority tickets (at least 1),
+// so with the usual priorities of 1 to 50 a higher priority means proportionally more CPU.
+// The draws come from a generator seeded with seed, so the same seed always gives the same schedule.
+func LotterySchedule(w io.Writer, title string, processes []Process, seed int64) ScheduleResult {
+	if !schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := lottery(processes, rand.New(rand.NewSource(seed)))
+	result.Title = title
+	outputResult(w, result)
+
+	return result
+}
+
+func lotteryTickets(p Process) int64 {
+	if tickets := lotteryMaxTickets - p.Priority; tickets > 1 {
+		return tickets
+	}
+	return 1
+}
+
+func lottery(processes []Process, rng *rand.Rand) ScheduleResult {
+	var serviceTime int64
+	schedule := make([]ScheduleRow, len(processes))
+	gantt := make([]TimeSlice, 0)
+
+	order := arrivalOrder(processes, TieDefault)
+
+	remaining := make([]int64, len(processes))
+	firstStart := make([]int64, len(processes))
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+		firstStart[i] = -1
+	}
+
+	var (
+		ready   []int
+		arrived int
+	)
+	for done := 0; done < len(processes); {
+		for arrived < len(order) && processes[order[arrived]].ArrivalTime <= serviceTime {
+			ready = append(ready, order[arrived])
+			arrived++
+		}
+		if len(ready) == 0 {
+			// No available jobs, jump to the next arrival.
+			serviceTime = processes[order[arrived]].ArrivalTime
+			continue
+		}
+
+		var total int64
+		for _, i := range ready {
+			total += lotteryTickets(processes[i])
+		}
+		winner := 0
+		for draw := rng.Int63n(total); ; winner++ {
+			if draw -= lotteryTickets(processes[ready[winner]]); draw < 0 {
+				break
+			}
+		}
+		i := ready[winner]
+
+		start := serviceTime
+		serviceTime++
+		remaining[i]--
+		if firstStart[i] < 0 {
+			firstStart[i] = start
+		}
+
+		gantt = append(gantt, TimeSlice{
+			PID:   processes[i].ProcessID,
+			Start: start,
+			Stop:  serviceTime,
+		})
+
+		if remaining[i] > 0 {
+			continue
+		}
+		ready = append(ready[:winner], ready[winner+1:]...)
+		done++
+
+		completion := serviceTime
+
+		turnaround := completion - processes[i].ArrivalTime
+
+		waitingTime := turnaround - processes[i].BurstDuration
+
+		response := firstStart[i] - processes[i].ArrivalTime
+
+		schedule[i] = ScheduleRow{
+			ProcessID:     processes[i].ProcessID,
+			Priority:      processes[i].Priority,
+			BurstDuration: processes[i].BurstDuration,
+			ArrivalTime:   processes[i].ArrivalTime,
+			Wait:          waitingTime,
+			Turnaround:    turnaround,
+			Completion:    completion,
+			Response:      response,
+		}
+	}
+
+	return newScheduleResult(gantt, schedule)
+}
+
+// fairScale is the virtual runtime a process of weight 1 accrues per unit of CPU time.
+// It is large so that dividing by the weight loses little to rounding.
+const fairScale = 1 << 16
+
+// WeightedFairSchedule outputs and returns a proportional-share schedule, with Priority as each process's weight.
+// Every unit of CPU time goes to the ready process with the least virtual runtime, which grows by fairScale / weight
+// per unit run, so over time a process of weight 2 runs twice as long as one of weight 1. Ties go to input order.
+// A process arrives with the least virtual runtime of those already ready, so it cannot catch up on time it was not waiting.
+// A Priority below 1 counts as a weight of 1. The CPU time each process received is reported against its fair share.
+func WeightedFairSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	if !schedulable(w, processes) {
+		return ScheduleResult{Title: title}
+	}
+	processes = cloneProcesses(processes)
+
+	result := weightedFair(processes)
+	result.Title = title
+	outputResult(w, result)
+	outputFairShares(w, result.FairShares)
+
+	return result
+}
+
+func fairWeight(p Process) int64 {
+	if p.Priority > 1 {
+		return p.Priority
+	}
+	return 1
+}
+
+func weightedFair(processes []Process) ScheduleResult {
+	var serviceTime int64
+	schedule := make([]ScheduleRow, len(processes))
+	gantt := make([]TimeSlice, 0)
+
+	order := arrivalOrder(processes, TieDefault)
+
+	remaining := make([]int64, len(processes))
+	firstStart := make([]int64, len(processes))
+	vruntime := make([]int64, len(processes))
+	shares := make([]FairShare, len(processes))
+	for i, p := range processes {
+		remaining[i] = p.BurstDuration
+		firstStart[i] = -1
+		shares[i] = FairShare{ProcessID: p.ProcessID, Weight: fairWeight(p)}
+	}
+
+	var (
+		ready   = make([]bool, len(processes))
+		arrived int
+		// floor is the least virtual runtime of the ready processes, which new arrivals start from.
+		floor int64
+	)
+	for done := 0; done < len(processes); {
+		for arrived < len(order) && processes[order[arrived]].ArrivalTime <= serviceTime {
+			vruntime[order[arrived]] = floor
+			ready[order[arrived]] = true
+			arrived++
+		}
+
+		next := -1
+		var totalWeight int64
+		for i := range processes {
+			if !ready[i] {
+				continue
+			}
+			totalWeight += shares[i].Weight
+			if next < 0 || vruntime[i] < vruntime[next] {
+				next = i
+			}
+		}
+		if next < 0 {
+			// No available jobs, jump to the next arrival.
+			serviceTime = processes[order[arrived]].ArrivalTime
+			continue
+		}
+		for i := range processes {
+			if ready[i] {
+				shares[i].Fair += float64(shares[i].Weight) / float64(totalWeight)
+			}
+		}
+
+		i := next
+		start := serviceTime
+		serviceTime++
+		remaining[i]--
+		shares[i].Actual++
+		vruntime[i] += fairScale / shares[i].Weight
+		if firstStart[i] < 0 {
+			firstStart[i] = start
+		}
+
+		if last := len(gantt) - 1; last >= 0 && gantt[last].PID == processes[i].ProcessID && gantt[last].Stop == start {
+			gantt[last].Stop = serviceTime
+		} else {
+			gantt = append(gantt, TimeSlice{
+				PID:   processes[i].ProcessID,
+				Start: start,
+				Stop:  serviceTime,
+			})
+		}
+
+		if remaining[i] == 0 {
+			ready[i] = false
+			done++
+
+			completion := serviceTime
+
+			turnaround := completion - processes[i].ArrivalTime
+
+			waitingTime := turnaround - processes[i].BurstDuration
+
+			response := firstStart[i] - processes[i].ArrivalTime
+
+			schedule[i] = ScheduleRow{
+				ProcessID:     processes[i].ProcessID,
+				Priority:      processes[i].Priority,
+				BurstDuration: processes[i].BurstDuration,
+				ArrivalTime:   processes[i].ArrivalTime,
+				Wait:          waitingTime,
+				Turnaround:    turnaround,
+				Completion:    completion,
+				Response:      response,
+			}
+		}
+
+		floor = -1
+		for j := range processes {
+			if ready[j] && (floor < 0 || vruntime[j] < floor) {
+				floor = vruntime[j]
+			}
+		}
+		if floor < 0 {
+			// Nothing is ready, so the next arrival starts afresh from the last process to run.
+			floor = vruntime[i]
+		}
+	}
+
+	result := newScheduleResult(gantt, schedule)
+	result.FairShares = shares
+
+	return result
+}
+
+// traceSelection writes a WithTrace line for choosing process chosen over the others ready at time t,
+// with key describing each one, such as "t=5 selected P3 (burst=2) over P2 (burst=4)".
+func traceSelection(w io.Writer, t int64, processes []Process, chosen int, others []int, key func(i int) string) {
+	line := fmt.Sprintf("t=%d selected %s (%s)", t, processes[chosen].ProcessID, key(chosen))
+	for k, i := range others {
+		sep := ", "
+		if k == 0 {
+			sep = " over "
+		}
+		line += fmt.Sprintf("%s%s (%s)", sep, processes[i].ProcessID, key(i))
+	}
+	_, _ = fmt.Fprintln(w, line)
+}
+
+// arrivalOrder returns the indexes of processes in arrival order, with simultaneous arrivals ordered by tie.
+func arrivalOrder(processes []Process, tie TieBreak) []int {
+	return orderBy(processes, tie, func(p Process) int64 { return p.ArrivalTime })
+}
+
+// eligibleOrder is arrivalOrder by the time each process becomes eligible to run.
+func eligibleOrder(processes []Process, tie TieBreak) []int {
+	return orderBy(processes, tie, eligibleAt)
+}
+
+// eligibleAt is the time p may first run: its arrival, or its NotBefore if that is later.
+func eligibleAt(p Process) int64 {
+	return max(p.ArrivalTime, p.NotBefore)
+}
+
+// orderBy returns the indexes of processes sorted by at, with ties ordered by tie.
+func orderBy(processes []Process, tie TieBreak, at func(Process) int64) []int {
+	order := make([]int, len(processes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if at(processes[a]) != at(processes[b]) {
+			return at(processes[a]) < at(processes[b])
+		}
+		return tieLess(tie, processes, a, b)
+	})
+
+	return order
+}
 
 //endregion
@@ -0,0 +1,1792 @@
+package main
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRRSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		quantum   int64
+		processes []Process
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "zero quantum",
+			args: args{
+				quantum:   0,
+				processes: []Process{{ProcessID: "P1", BurstDuration: 1}},
+			},
+			wantOut: "invalid time quantum 0: must be greater than 0\n",
+		},
+		{
+			name: "negative quantum",
+			args: args{
+				quantum:   -2,
+				processes: []Process{{ProcessID: "P1", BurstDuration: 1}},
+			},
+			wantOut: "invalid time quantum -2: must be greater than 0\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			RRSchedule(&w, "Round-robin", tt.args.quantum, tt.args.processes)
+			if diff := cmp.Diff(w.String(), tt.wantOut); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func Test_roundRobin(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		quantum   int64
+		processes []Process
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantGantt    []TimeSlice
+		wantWait     float64
+		wantResponse float64
+	}{
+		{
+			name: "quantum 2",
+			args: args{
+				quantum: 2,
+				processes: []Process{
+					{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 5},
+					{ProcessID: "P2", ArrivalTime: 1, BurstDuration: 3},
+					{ProcessID: "P3", ArrivalTime: 2, BurstDuration: 1},
+					{ProcessID: "P4", ArrivalTime: 3, BurstDuration: 2},
+				},
+			},
+			wantGantt: []TimeSlice{
+				{PID: "P1", Start: 0, Stop: 2, Preempted: true},
+				{PID: "P2", Start: 2, Stop: 4, Preempted: true},
+				{PID: "P3", Start: 4, Stop: 5},
+				{PID: "P1", Start: 5, Stop: 7, Preempted: true},
+				{PID: "P4", Start: 7, Stop: 9},
+				{PID: "P2", Start: 9, Stop: 10},
+				{PID: "P1", Start: 10, Stop: 11},
+			},
+			// P1 6, P2 6, P3 2, P4 4
+			wantWait: 4.5,
+			// P1 0, P2 1, P3 2, P4 4
+			wantResponse: 1.75,
+		},
+		{
+			name: "merges when nothing is waiting",
+			args: args{
+				quantum: 1,
+				processes: []Process{
+					{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: "P2", ArrivalTime: 10, BurstDuration: 1},
+				},
+			},
+			wantGantt: []TimeSlice{
+				{PID: "P1", Start: 0, Stop: 4},
+				{PID: "P2", Start: 10, Stop: 11},
+			},
+			wantWait:     0,
+			wantResponse: 0,
+		},
+		{
+			name: "simultaneous arrivals at a quantum boundary",
+			args: args{
+				quantum: 2,
+				processes: []Process{
+					{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: "P3", ArrivalTime: 2, BurstDuration: 1},
+					{ProcessID: "P1", ArrivalTime: 2, BurstDuration: 1},
+					{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 1},
+				},
+			},
+			// The arrivals are queued in input order, then the preempted P0.
+			wantGantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2, Preempted: true},
+				{PID: "P3", Start: 2, Stop: 3},
+				{PID: "P1", Start: 3, Stop: 4},
+				{PID: "P2", Start: 4, Stop: 5},
+				{PID: "P0", Start: 5, Stop: 7},
+			},
+			// P0 3, P3 0, P1 1, P2 2
+			wantWait: 1.5,
+			// P0 0, P3 0, P1 1, P2 2
+			wantResponse: 0.75,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := roundRobin(tt.args.processes, tt.args.quantum, options{})
+			if diff := cmp.Diff(got.Gantt, tt.wantGantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if got.AveWait != tt.wantWait {
+				t.Errorf("AveWait = %v, want %v", got.AveWait, tt.wantWait)
+			}
+			if got.AveResponse != tt.wantResponse {
+				t.Errorf("AveResponse = %v, want %v", got.AveResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_shortestRemainingTime(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: "P2", ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: "P3", ArrivalTime: 2, BurstDuration: 9},
+		{ProcessID: "P4", ArrivalTime: 3, BurstDuration: 5},
+	}
+	wantGantt := []TimeSlice{
+		{PID: "P1", Start: 0, Stop: 1},
+		{PID: "P2", Start: 1, Stop: 5},
+		{PID: "P4", Start: 5, Stop: 10},
+		{PID: "P1", Start: 10, Stop: 17},
+		{PID: "P3", Start: 17, Stop: 26},
+	}
+
+	got := shortestRemainingTime(processes, options{})
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+	// P1 9, P2 0, P3 15, P4 2
+	if got.AveWait != 6.5 {
+		t.Errorf("AveWait = %v, want %v", got.AveWait, 6.5)
+	}
+
+	// Non-preemptive SJF lets P1 run to completion: P1 0, P2 7, P3 15, P4 9.
+	if sjf := shortestJobFirst(processes, options{}); sjf.AveWait != 7.75 {
+		t.Errorf("SJF AveWait = %v, want %v", sjf.AveWait, 7.75)
+	}
+}
+
+func Test_shortestRemainingTime_tie(t *testing.T) {
+	t.Parallel()
+	// B arrives as A is down to the same remaining time, so A keeps the CPU and there is only the one switch to B.
+	got := shortestRemainingTime([]Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "B", ArrivalTime: 2, BurstDuration: 2},
+	}, newOptions([]Option{WithSwitchCost(1)}))
+	wantGantt := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 4},
+		{Start: 4, Stop: 5, Switch: true},
+		{PID: "B", Start: 5, Stop: 7},
+	}
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+// unitStepPreemptive is preemptive without aging or switch costs, advancing one time unit at a time and choosing
+// afresh at every unit rather than only at arrivals and completions, kept as a reference for it.
+func unitStepPreemptive(processes []Process, less func(remaining []int64, i, j int) bool) ScheduleResult {
+	schedule := make([]ScheduleRow, len(processes))
+	gantt := make([]TimeSlice, 0)
+	order := arrivalOrder(processes, TieDefault)
+	remaining := make([]int64, len(processes))
+	firstStart := make([]int64, len(processes))
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+		firstStart[i] = -1
+	}
+
+	var (
+		serviceTime int64
+		ready       []int
+		arrived     int
+		running     = -1
+	)
+	for done := 0; done < len(processes); serviceTime++ {
+		for arrived < len(order) && processes[order[arrived]].ArrivalTime <= serviceTime {
+			ready = append(ready, order[arrived])
+			arrived++
+		}
+		if len(ready) == 0 {
+			continue
+		}
+		next := 0
+		for r := range ready {
+			if ready[r] == running {
+				next = r
+			}
+		}
+		for r := range ready {
+			if less(remaining, ready[r], ready[next]) {
+				next = r
+			}
+		}
+		i := ready[next]
+		running = i
+
+		if firstStart[i] < 0 {
+			firstStart[i] = serviceTime
+		}
+		remaining[i]--
+		if last := len(gantt) - 1; last >= 0 && gantt[last].PID == processes[i].ProcessID && gantt[last].Stop == serviceTime {
+			gantt[last].Stop++
+		} else {
+			gantt = append(gantt, TimeSlice{PID: processes[i].ProcessID, Start: serviceTime, Stop: serviceTime + 1})
+		}
+		if remaining[i] > 0 {
+			continue
+		}
+		ready = append(ready[:next], ready[next+1:]...)
+		done++
+
+		p := processes[i]
+		turnaround := serviceTime + 1 - p.ArrivalTime
+		schedule[i] = ScheduleRow{
+			ProcessID:     p.ProcessID,
+			Priority:      p.Priority,
+			BurstDuration: p.BurstDuration,
+			ArrivalTime:   p.ArrivalTime,
+			Wait:          turnaround - p.BurstDuration,
+			Turnaround:    turnaround,
+			Completion:    serviceTime + 1,
+			Response:      firstStart[i] - p.ArrivalTime,
+		}
+	}
+
+	return newScheduleResult(gantt, schedule)
+}
+
+// preemptiveReferences pairs each preemptive scheduler with its unit-step reference.
+var preemptiveReferences = map[string]struct {
+	eventDriven func([]Process) ScheduleResult
+	unitStep    func([]Process) ScheduleResult
+}{
+	"SRTF": {
+		eventDriven: func(processes []Process) ScheduleResult { return shortestRemainingTime(processes, options{}) },
+		unitStep: func(processes []Process) ScheduleResult {
+			return unitStepPreemptive(processes, func(remaining []int64, i, j int) bool { return remaining[i] < remaining[j] })
+		},
+	},
+	"PreemptivePriority": {
+		eventDriven: func(processes []Process) ScheduleResult { return preemptivePriority(processes, options{}) },
+		unitStep: func(processes []Process) ScheduleResult {
+			return unitStepPreemptive(processes, func(_ []int64, i, j int) bool { return processes[i].Priority < processes[j].Priority })
+		},
+	},
+}
+
+// largeBursts returns processes whose bursts are long next to the gaps between their arrivals,
+// so most of a unit-step schedule is spent inside bursts.
+func largeBursts(n int, seed int64) []Process {
+	return GenerateProcesses(n, seed, GenOptions{MaxArrival: int64(n) * 100, MinBurst: 100, MaxBurst: 1000})
+}
+
+func Test_preemptive_unitStep(t *testing.T) {
+	t.Parallel()
+	for name, schedulers := range preemptiveReferences {
+		name, schedulers := name, schedulers
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for seed := int64(1); seed <= 10; seed++ {
+				processes := largeBursts(30, seed)
+				if diff := cmp.Diff(schedulers.eventDriven(processes), schedulers.unitStep(processes)); diff != "" {
+					t.Fatalf("seed %d: %s", seed, diff)
+				}
+			}
+		})
+	}
+}
+
+func Benchmark_preemptive(b *testing.B) {
+	processes := largeBursts(200, 1)
+	for name, schedulers := range preemptiveReferences {
+		if diff := cmp.Diff(schedulers.eventDriven(processes), schedulers.unitStep(processes)); diff != "" {
+			b.Fatalf("%s: %s", name, diff)
+		}
+		for mode, schedule := range map[string]func([]Process) ScheduleResult{
+			"event-driven": schedulers.eventDriven,
+			"unit-step":    schedulers.unitStep,
+		} {
+			schedule := schedule
+			b.Run(name+"/"+mode, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					schedule(processes)
+				}
+			})
+		}
+	}
+}
+
+func TestSJFPrioritySchedule_waiting(t *testing.T) {
+	t.Parallel()
+	// P0 runs 0-1 and P1 runs 1-2, so they wait 0 and 1 whatever their priorities.
+	got := SJFPrioritySchedule(io.Discard, "Shortest-job-first priority", []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 1, Priority: 3},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 1, Priority: 5},
+	})
+	if got.AveWait != 0.5 {
+		t.Errorf("AveWait = %v, want %v", got.AveWait, 0.5)
+	}
+	if got.AveTurnaround != 1.5 {
+		t.Errorf("AveTurnaround = %v, want %v", got.AveTurnaround, 1.5)
+	}
+}
+
+func TestSJFPrioritySchedule_staggered(t *testing.T) {
+	t.Parallel()
+	// P0 runs 0-3, then P2 3-4 as the shorter of the two waiting, then P1 4-6.
+	// A process queued twice would run again and push the end past 6.
+	got := SJFPrioritySchedule(io.Discard, "Shortest-job-first priority", []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 1},
+	})
+	// P0 0, P1 3, P2 1
+	if got.AveWait != 4.0/3 {
+		t.Errorf("AveWait = %v, want %v", got.AveWait, 4.0/3)
+	}
+	// P0 3, P1 5, P2 2
+	if got.AveTurnaround != 10.0/3 {
+		t.Errorf("AveTurnaround = %v, want %v", got.AveTurnaround, 10.0/3)
+	}
+	if got.Throughput != 0.5 {
+		t.Errorf("Throughput = %v, want %v", got.Throughput, 0.5)
+	}
+}
+
+func TestSJFPrioritySchedule_output(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	got := SJFPrioritySchedule(&w, "Shortest-job-first priority", []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+		{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+	})
+	// The ticks of each process are merged into one slice.
+	wantGantt := []TimeSlice{
+		{PID: "P0", Start: 0, Stop: 3},
+		{PID: "P2", Start: 3, Stop: 4},
+		{PID: "P1", Start: 4, Stop: 6},
+	}
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+
+	out := w.String()
+	for _, want := range []string{
+		"Gantt schedule\n|  P0  |  P2  |  P1  |\n0      3      4      6\n",
+		"Schedule table\n",
+		"| P0 |        2 |     3 |       0 |    0 |          3 |    3 |",
+		"| P1 |        1 |     2 |       1 |    3 |          5 |    6 |",
+		"| P2 |        3 |     1 |       2 |    1 |          2 |    4 |",
+		"Average wait: 1.33\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestSJFSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "X1", ArrivalTime: 0, BurstDuration: 6, Priority: 1},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3, Priority: 2},
+		{ProcessID: "A", ArrivalTime: 1, BurstDuration: 1, Priority: 3},
+	}
+	// Runs X1, A, B but the table keeps the input order.
+	wantRows := []string{
+		"| X1 |        1 |     6 |       0 |    0 |          6 |    6 |",
+		"| B  |        2 |     3 |       1 |    6 |          9 |   10 |",
+		"| A  |        3 |     1 |       1 |    5 |          6 |    7 |",
+	}
+
+	var w bytes.Buffer
+	SJFSchedule(&w, "Shortest-job-first", processes)
+	out := w.String()
+	last := -1
+	for _, row := range wantRows {
+		at := strings.Index(out, row)
+		if at == -1 {
+			t.Fatalf("missing row %q in:\n%s", row, out)
+		}
+		if at < last {
+			t.Errorf("row %q out of order in:\n%s", row, out)
+		}
+		last = at
+	}
+}
+
+func TestSingleProcess(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		Row        ScheduleRow
+		Idle       int64
+		Throughput float64
+	}
+	schedulers := map[string]func(io.Writer, string, []Process, ...Option) ScheduleResult{
+		"FCFS": FCFSSchedule,
+		"SJF":  SJFSchedule,
+	}
+	tests := []struct {
+		name    string
+		arrival int64
+		want    want
+	}{
+		{
+			name: "arrives at 0",
+			want: want{
+				Row:        ScheduleRow{ProcessID: "P0", BurstDuration: 5, Turnaround: 5, Completion: 5},
+				Throughput: 1.0 / 5,
+			},
+		},
+		{
+			name:    "arrives at 10",
+			arrival: 10,
+			// The CPU idles until the arrival, but the process itself never waits.
+			want: want{
+				Row:        ScheduleRow{ProcessID: "P0", BurstDuration: 5, ArrivalTime: 10, Turnaround: 5, Completion: 15},
+				Idle:       10,
+				Throughput: 1.0 / 15,
+			},
+		},
+	}
+	for name, schedule := range schedulers {
+		name, schedule := name, schedule
+		for _, tt := range tests {
+			tt := tt
+			t.Run(name+" "+tt.name, func(t *testing.T) {
+				t.Parallel()
+				result := schedule(io.Discard, name, []Process{{ProcessID: "P0", ArrivalTime: tt.arrival, BurstDuration: 5}})
+				if len(result.Rows) != 1 {
+					t.Fatalf("got %d rows, want 1", len(result.Rows))
+				}
+				_, _, idle := CPUUsage(result.Gantt)
+				got := want{Row: result.Rows[0], Idle: idle, Throughput: result.Throughput}
+				if diff := cmp.Diff(got, tt.want); diff != "" {
+					t.Errorf(diff)
+				}
+			})
+		}
+	}
+}
+
+func TestNotBefore(t *testing.T) {
+	t.Parallel()
+	schedulers := map[string]func(io.Writer, string, []Process, ...Option) ScheduleResult{
+		"FCFS": FCFSSchedule,
+		"SJF":  SJFSchedule,
+	}
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantRows  []ScheduleRow
+	}{
+		{
+			name:      "idles until eligible",
+			processes: []Process{{ProcessID: "P0", BurstDuration: 2, NotBefore: 5}},
+			wantGantt: []TimeSlice{{PID: "P0", Start: 5, Stop: 7}},
+			wantRows:  []ScheduleRow{{ProcessID: "P0", BurstDuration: 2, Wait: 5, Turnaround: 7, Completion: 7, Response: 5}},
+		},
+		{
+			name: "later arrival runs first",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 2, NotBefore: 5},
+				{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 3},
+			},
+			wantGantt: []TimeSlice{{PID: "P1", Start: 1, Stop: 4}, {PID: "P0", Start: 5, Stop: 7}},
+			wantRows: []ScheduleRow{
+				{ProcessID: "P0", BurstDuration: 2, Wait: 5, Turnaround: 7, Completion: 7, Response: 5},
+				{ProcessID: "P1", BurstDuration: 3, ArrivalTime: 1, Turnaround: 3, Completion: 4},
+			},
+		},
+	}
+	for name, schedule := range schedulers {
+		name, schedule := name, schedule
+		for _, tt := range tests {
+			tt := tt
+			t.Run(name+" "+tt.name, func(t *testing.T) {
+				t.Parallel()
+				got := schedule(io.Discard, name, tt.processes)
+				if diff := cmp.Diff(got.Gantt, tt.wantGantt); diff != "" {
+					t.Errorf(diff)
+				}
+				if diff := cmp.Diff(got.Rows, tt.wantRows); diff != "" {
+					t.Errorf(diff)
+				}
+			})
+		}
+	}
+}
+
+func TestWithLookahead(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantWait  float64
+	}{
+		{
+			// Online SJF starts L and S waits 9; idling a unit for S costs L only 2.
+			name: "idles for a short job",
+			processes: []Process{
+				{ProcessID: "L", BurstDuration: 10},
+				{ProcessID: "S", ArrivalTime: 1, BurstDuration: 1},
+			},
+			wantGantt: []TimeSlice{{PID: "S", Start: 1, Stop: 2}, {PID: "L", Start: 2, Stop: 12}},
+			wantWait:  1,
+		},
+		{
+			// Idling would cost more than S waiting for the short L.
+			name: "no gain from idling",
+			processes: []Process{
+				{ProcessID: "L", BurstDuration: 2},
+				{ProcessID: "S", ArrivalTime: 1, BurstDuration: 1},
+			},
+			wantGantt: []TimeSlice{{PID: "L", Start: 0, Stop: 2}, {PID: "S", Start: 2, Stop: 3}},
+			wantWait:  0.5,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := SJFSchedule(io.Discard, "SJF", tt.processes, WithLookahead())
+			if diff := cmp.Diff(got.Gantt, tt.wantGantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if got.AveWait != tt.wantWait {
+				t.Errorf("AveWait = %v, want %v", got.AveWait, tt.wantWait)
+			}
+		})
+	}
+
+	// Without lookahead, the same input as the first case starts L straight away.
+	online := SJFSchedule(io.Discard, "SJF", tests[0].processes)
+	if diff := cmp.Diff(online.Gantt, []TimeSlice{{PID: "L", Start: 0, Stop: 10}, {PID: "S", Start: 10, Stop: 11}}); diff != "" {
+		t.Errorf(diff)
+	}
+	if online.AveWait != 4.5 {
+		t.Errorf("online AveWait = %v, want 4.5", online.AveWait)
+	}
+
+	// On random input lookahead still runs every job exactly once and never waits longer in total.
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 50; n++ {
+		processes := make([]Process, 6)
+		for i := range processes {
+			processes[i] = Process{ProcessID: fmt.Sprintf("P%d", i), ArrivalTime: rng.Int63n(10), BurstDuration: 1 + rng.Int63n(8)}
+		}
+		ahead := shortestJobFirst(processes, newOptions([]Option{WithLookahead()}))
+		var busy int64
+		for _, slice := range ahead.Gantt {
+			busy += slice.Stop - slice.Start
+		}
+		var total int64
+		for _, p := range processes {
+			total += p.BurstDuration
+		}
+		if len(ahead.Gantt) != len(processes) || busy != total {
+			t.Fatalf("lookahead ran %d slices for %d units, want %d for %d: %v", len(ahead.Gantt), busy, len(processes), total, processes)
+		}
+		if plain := shortestJobFirst(processes, options{}); ahead.AveWait > plain.AveWait {
+			t.Errorf("lookahead AveWait %v > online %v for %v", ahead.AveWait, plain.AveWait, processes)
+		}
+	}
+}
+
+func TestWithWaitCap(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: "L", ArrivalTime: 1, BurstDuration: 8},
+		{ProcessID: "S1", ArrivalTime: 2, BurstDuration: 2},
+		{ProcessID: "S2", ArrivalTime: 4, BurstDuration: 2},
+	}
+	tests := []struct {
+		name           string
+		opts           []Option
+		wantOrder      []string
+		wantPromotions int
+	}{
+		{
+			name:      "uncapped",
+			wantOrder: []string{"A", "S1", "S2", "L"},
+		},
+		{
+			// At 5 L has waited 4, past the cap, so it runs ahead of the shorter S2.
+			name:           "capped",
+			opts:           []Option{WithWaitCap(3)},
+			wantOrder:      []string{"A", "S1", "L", "S2"},
+			wantPromotions: 1,
+		},
+		{
+			name:      "cap never reached",
+			opts:      []Option{WithWaitCap(10)},
+			wantOrder: []string{"A", "S1", "S2", "L"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			result := SJFSchedule(&w, "SJF", processes, tt.opts...)
+			var order []string
+			for _, slice := range result.Gantt {
+				order = append(order, slice.PID)
+			}
+			if diff := cmp.Diff(order, tt.wantOrder); diff != "" {
+				t.Errorf(diff)
+			}
+			if result.CapPromotions != tt.wantPromotions {
+				t.Errorf("CapPromotions = %d, want %d", result.CapPromotions, tt.wantPromotions)
+			}
+			if want := fmt.Sprintf("Cap promotions: %d\n", tt.wantPromotions); len(tt.opts) > 0 && !strings.Contains(w.String(), want) {
+				t.Errorf("missing %q in:\n%s", want, w.String())
+			}
+		})
+	}
+}
+
+func TestWithZeroBursts(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", BurstDuration: 4},
+		{ProcessID: "M", ArrivalTime: 2},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 2},
+	}
+	without := []Process{processes[0], processes[2]}
+	schedulers := map[string]func(io.Writer, string, []Process, ...Option) ScheduleResult{
+		"FCFS": FCFSSchedule,
+		"SJF":  SJFSchedule,
+		"SRTF": SRTFSchedule,
+		"Priority": func(w io.Writer, title string, processes []Process, opts ...Option) ScheduleResult {
+			return PrioritySchedule(w, title, processes, true, opts...)
+		},
+		"RR": func(w io.Writer, title string, processes []Process, opts ...Option) ScheduleResult {
+			return RRSchedule(w, title, 2, processes, opts...)
+		},
+	}
+	for name, schedule := range schedulers {
+		name, schedule := name, schedule
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if got := schedule(&w, name, processes); len(got.Rows) != 0 {
+				t.Errorf("zero burst scheduled by default: %+v", got.Rows)
+			}
+			if want := `invalid process: "M" has a zero burst duration and would never run` + "\n"; w.String() != want {
+				t.Errorf("output = %q, want %q", w.String(), want)
+			}
+
+			want := schedule(io.Discard, name, without)
+			skipped := schedule(io.Discard, name, processes, WithZeroBursts(ZeroBurstsSkipped))
+			if diff := cmp.Diff(skipped, want); diff != "" {
+				t.Errorf(diff)
+			}
+
+			instant := schedule(io.Discard, name, processes, WithZeroBursts(ZeroBurstsInstant))
+			if diff := cmp.Diff(instant.Gantt, want.Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+			wantRows := []ScheduleRow{want.Rows[0], {ProcessID: "M", ArrivalTime: 2, Completion: 2}, want.Rows[1]}
+			if diff := cmp.Diff(instant.Rows, wantRows); diff != "" {
+				t.Errorf(diff)
+			}
+			// M's wait of 0 brings the average down by a third.
+			if wantWait := want.AveWait * 2 / 3; instant.AveWait != wantWait {
+				t.Errorf("AveWait = %v, want %v", instant.AveWait, wantWait)
+			}
+		})
+	}
+
+	// A marker after the other work ends extends the makespan.
+	late := append([]Process{}, without...)
+	late = append(late, Process{ProcessID: "M", ArrivalTime: 10})
+	if got := FCFSSchedule(io.Discard, "FCFS", late, WithZeroBursts(ZeroBurstsInstant)); got.Makespan != 10 {
+		t.Errorf("Makespan = %d, want 10", got.Makespan)
+	}
+}
+
+func TestWithQuiet(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", BurstDuration: 4},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 1},
+	}
+	// FCFS waits P0 0, P1 3, P2 5 and turns around 4, 6, 6, completing all three by 8.
+	var w bytes.Buffer
+	FCFSSchedule(&w, "FCFS", processes, WithQuiet())
+	if want := "avgWait=2.6666666666666665 avgTurnaround=5.333333333333333 throughput=0.375\n"; w.String() != want {
+		t.Errorf("output = %q, want %q", w.String(), want)
+	}
+
+	schedulers := map[string]func(io.Writer, string, []Process, ...Option) ScheduleResult{
+		"FCFS": FCFSSchedule,
+		"SJF": func(w io.Writer, title string, processes []Process, opts ...Option) ScheduleResult {
+			return SJFSchedule(w, title, processes, append(opts, WithWaitCap(1))...)
+		},
+		"SRTF": SRTFSchedule,
+		"Priority": func(w io.Writer, title string, processes []Process, opts ...Option) ScheduleResult {
+			return PrioritySchedule(w, title, processes, false, opts...)
+		},
+		"RR": func(w io.Writer, title string, processes []Process, opts ...Option) ScheduleResult {
+			return RRSchedule(w, title, 2, processes, opts...)
+		},
+	}
+	for name, schedule := range schedulers {
+		name, schedule := name, schedule
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			got := schedule(&w, name, processes, WithQuiet())
+			if diff := cmp.Diff(got, schedule(io.Discard, name, processes)); diff != "" {
+				t.Errorf("quiet changed the schedule: %s", diff)
+			}
+			var want bytes.Buffer
+			outputSummary(&want, got)
+			if diff := cmp.Diff(w.String(), want.String()); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func Test_jobHeap(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		jobs []Process
+		want []string
+	}{
+		{
+			name: "shortest burst",
+			jobs: []Process{
+				{ProcessID: "A", BurstDuration: 3},
+				{ProcessID: "B", BurstDuration: 2},
+			},
+			want: []string{"B", "A"},
+		},
+		{
+			name: "equal burst goes to smaller ID",
+			jobs: []Process{
+				{ProcessID: "P2", BurstDuration: 4},
+				{ProcessID: "P1", BurstDuration: 4},
+			},
+			want: []string{"P1", "P2"},
+		},
+		{
+			name: "mixed",
+			jobs: []Process{
+				{ProcessID: "C", BurstDuration: 5},
+				{ProcessID: "B", BurstDuration: 1},
+				{ProcessID: "E", BurstDuration: 3},
+				{ProcessID: "A", BurstDuration: 5},
+				{ProcessID: "D", BurstDuration: 3},
+			},
+			want: []string{"B", "D", "E", "A", "C"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := jobHeap{processes: tt.jobs}
+			for i := range tt.jobs {
+				heap.Push(&h, i)
+			}
+			var got []string
+			for h.Len() > 0 {
+				got = append(got, tt.jobs[heap.Pop(&h).(int)].ProcessID)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+
+	// The smaller ID also runs first in the full schedule.
+	got := shortestJobFirst([]Process{
+		{ProcessID: "P2", BurstDuration: 4},
+		{ProcessID: "P1", BurstDuration: 4},
+	}, options{})
+	if got.Gantt[0].PID != "P1" {
+		t.Errorf("%s ran first, want P1", got.Gantt[0].PID)
+	}
+}
+
+// naiveShortestJobFirst is the straightforward SJF that rebuilds the list of waiting jobs after every pick,
+// kept as a reference for shortestJobFirst.
+func naiveShortestJobFirst(processes []Process) ScheduleResult {
+	var serviceTime int64
+	schedule := make([]ScheduleRow, len(processes))
+	gantt := make([]TimeSlice, 0)
+	remaining := make([]int, len(processes))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	for len(remaining) > 0 {
+		next := -1
+		for r, i := range remaining {
+			p := processes[i]
+			if p.ArrivalTime > serviceTime {
+				continue
+			}
+			if next < 0 {
+				next = r
+				continue
+			}
+			q := processes[remaining[next]]
+			if p.BurstDuration < q.BurstDuration || p.BurstDuration == q.BurstDuration && p.ProcessID < q.ProcessID {
+				next = r
+			}
+		}
+		if next < 0 {
+			serviceTime++
+			continue
+		}
+		i := remaining[next]
+		remaining = append(append([]int{}, remaining[:next]...), remaining[next+1:]...)
+
+		p := processes[i]
+		completion := serviceTime + p.BurstDuration
+		schedule[i] = ScheduleRow{
+			ProcessID:     p.ProcessID,
+			Priority:      p.Priority,
+			BurstDuration: p.BurstDuration,
+			ArrivalTime:   p.ArrivalTime,
+			Wait:          serviceTime - p.ArrivalTime,
+			Turnaround:    completion - p.ArrivalTime,
+			Completion:    completion,
+			Response:      serviceTime - p.ArrivalTime,
+		}
+		gantt = append(gantt, TimeSlice{PID: p.ProcessID, Start: serviceTime, Stop: completion})
+		serviceTime = completion
+	}
+
+	return newScheduleResult(gantt, schedule)
+}
+
+// randomProcesses returns n processes with bursts of 1 to 20 arriving over the first n*5 time units.
+func Test_shortestJobFirst_unsorted(t *testing.T) {
+	t.Parallel()
+	// Out of arrival order, and P0 runs from the middle of the ready jobs, so no scan may stop at the first
+	// process that has not yet arrived.
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 5, BurstDuration: 1},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 3},
+		{ProcessID: "P3", ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: "P4", ArrivalTime: 9, BurstDuration: 1},
+	}
+	got := shortestJobFirst(processes, options{})
+	want := []TimeSlice{
+		{PID: "P1", Start: 0, Stop: 8},
+		{PID: "P0", Start: 8, Stop: 9},
+		{PID: "P4", Start: 9, Stop: 10},
+		{PID: "P3", Start: 10, Stop: 12},
+		{PID: "P2", Start: 12, Stop: 15},
+	}
+	if diff := cmp.Diff(got.Gantt, want); diff != "" {
+		t.Errorf(diff)
+	}
+	if diff := cmp.Diff(got, naiveShortestJobFirst(processes)); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func Test_shortestJobFirst_large(t *testing.T) {
+	t.Parallel()
+	processes := GenerateProcesses(1000, 1, GenOptions{})
+	if diff := cmp.Diff(shortestJobFirst(processes, options{}), naiveShortestJobFirst(processes)); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestSJFSchedule_lateArrival(t *testing.T) {
+	t.Parallel()
+	// The CPU idles for almost a million units before P2 arrives, which must be jumped over rather than stepped through.
+	processes := []Process{
+		{ProcessID: "P0", BurstDuration: 3},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: "P2", ArrivalTime: 1_000_000, BurstDuration: 4},
+	}
+	got := SJFSchedule(io.Discard, "SJF", processes)
+	want := []TimeSlice{
+		{PID: "P0", Start: 0, Stop: 3},
+		{PID: "P1", Start: 3, Stop: 5},
+		{PID: "P2", Start: 1_000_000, Stop: 1_000_004},
+	}
+	if diff := cmp.Diff(got.Gantt, want); diff != "" {
+		t.Errorf(diff)
+	}
+	if got.Rows[2].Wait != 0 {
+		t.Errorf("P2 waited %d, want 0", got.Rows[2].Wait)
+	}
+	if _, _, idle := CPUUsage(got.Gantt); idle != 1_000_000-5 {
+		t.Errorf("idle time is %d, want %d", idle, 1_000_000-5)
+	}
+}
+
+func TestPriorityAndHRRN_lateArrival(t *testing.T) {
+	t.Parallel()
+	// As for SJF, the idle gap before P2 must be jumped over rather than stepped through.
+	processes := []Process{
+		{ProcessID: "P0", BurstDuration: 3, Priority: 1},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+		{ProcessID: "P2", ArrivalTime: 1_000_000, BurstDuration: 4, Priority: 3},
+	}
+	want := []TimeSlice{
+		{PID: "P0", Start: 0, Stop: 3},
+		{PID: "P1", Start: 3, Stop: 5},
+		{PID: "P2", Start: 1_000_000, Stop: 1_000_004},
+	}
+	tests := []struct {
+		name     string
+		schedule func() ScheduleResult
+	}{
+		{name: "priority", schedule: func() ScheduleResult { return PrioritySchedule(io.Discard, "Priority", processes, false) }},
+		{name: "preemptive priority", schedule: func() ScheduleResult { return PrioritySchedule(io.Discard, "Priority", processes, true) }},
+		{name: "HRRN", schedule: func() ScheduleResult { return HRRNSchedule(io.Discard, "HRRN", processes) }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.schedule()
+			if diff := cmp.Diff(got.Gantt, want); diff != "" {
+				t.Errorf(diff)
+			}
+			if got.Rows[2].Wait != 0 {
+				t.Errorf("P2 waited %d, want 0", got.Rows[2].Wait)
+			}
+		})
+	}
+}
+
+func Benchmark_shortestJobFirst(b *testing.B) {
+	processes := GenerateProcesses(1000, 1, GenOptions{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		shortestJobFirst(processes, options{})
+	}
+}
+
+func Benchmark_naiveShortestJobFirst(b *testing.B) {
+	processes := GenerateProcesses(1000, 1, GenOptions{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		naiveShortestJobFirst(processes)
+	}
+}
+
+func Test_highestPriority(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantWait  float64
+	}{
+		{
+			name: "tie resolved by arrival",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4, Priority: 3},
+				{ProcessID: "B", ArrivalTime: 2, BurstDuration: 2, Priority: 1},
+				{ProcessID: "C", ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+				{ProcessID: "D", ArrivalTime: 3, BurstDuration: 1, Priority: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 4},
+				{PID: "C", Start: 4, Stop: 7},
+				{PID: "B", Start: 7, Stop: 9},
+				{PID: "D", Start: 9, Stop: 10},
+			},
+			// A 0, B 5, C 3, D 6
+			wantWait: 3.5,
+		},
+		{
+			name: "idle until first arrival",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 2, BurstDuration: 1, Priority: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: "A", Start: 2, Stop: 3},
+			},
+			wantWait: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := highestPriority(tt.processes, options{})
+			if diff := cmp.Diff(got.Gantt, tt.wantGantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if got.AveWait != tt.wantWait {
+				t.Errorf("AveWait = %v, want %v", got.AveWait, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestPrioritySchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "L", ArrivalTime: 0, BurstDuration: 10, Priority: 5},
+		{ProcessID: "H1", ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+		{ProcessID: "H2", ArrivalTime: 5, BurstDuration: 1, Priority: 1},
+		{ProcessID: "H3", ArrivalTime: 8, BurstDuration: 2, Priority: 2},
+	}
+	tests := []struct {
+		name       string
+		preemptive bool
+		wantGantt  []TimeSlice
+	}{
+		{
+			name: "non-preemptive",
+			wantGantt: []TimeSlice{
+				{PID: "L", Start: 0, Stop: 10},
+				{PID: "H1", Start: 10, Stop: 12},
+				{PID: "H2", Start: 12, Stop: 13},
+				{PID: "H3", Start: 13, Stop: 15},
+			},
+		},
+		{
+			name:       "preemptive",
+			preemptive: true,
+			wantGantt: []TimeSlice{
+				{PID: "L", Start: 0, Stop: 1},
+				{PID: "H1", Start: 1, Stop: 3},
+				{PID: "L", Start: 3, Stop: 5},
+				{PID: "H2", Start: 5, Stop: 6},
+				{PID: "L", Start: 6, Stop: 8},
+				{PID: "H3", Start: 8, Stop: 10},
+				{PID: "L", Start: 10, Stop: 15},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			got := PrioritySchedule(&w, "Priority", processes, tt.preemptive)
+			if diff := cmp.Diff(got.Gantt, tt.wantGantt); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func Test_preemptivePriority(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantWait  float64
+	}{
+		{
+			name: "long low priority job repeatedly preempted",
+			processes: []Process{
+				{ProcessID: "L", ArrivalTime: 0, BurstDuration: 10, Priority: 5},
+				{ProcessID: "H1", ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+				{ProcessID: "H2", ArrivalTime: 5, BurstDuration: 1, Priority: 1},
+				{ProcessID: "H3", ArrivalTime: 8, BurstDuration: 2, Priority: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: "L", Start: 0, Stop: 1},
+				{PID: "H1", Start: 1, Stop: 3},
+				{PID: "L", Start: 3, Stop: 5},
+				{PID: "H2", Start: 5, Stop: 6},
+				{PID: "L", Start: 6, Stop: 8},
+				{PID: "H3", Start: 8, Stop: 10},
+				{PID: "L", Start: 10, Stop: 15},
+			},
+			// L 5, H1 0, H2 0, H3 0
+			wantWait: 1.25,
+		},
+		{
+			name: "equal priority and arrival falls back to input order",
+			processes: []Process{
+				{ProcessID: "B", ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 1, Priority: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: "B", Start: 0, Stop: 2},
+				{PID: "A", Start: 2, Stop: 3},
+			},
+			// B 0, A 2
+			wantWait: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := preemptivePriority(tt.processes, options{})
+			if diff := cmp.Diff(got.Gantt, tt.wantGantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if got.AveWait != tt.wantWait {
+				t.Errorf("AveWait = %v, want %v", got.AveWait, tt.wantWait)
+			}
+		})
+	}
+}
+
+func Test_highestResponseRatio(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 5},
+		{ProcessID: "C", ArrivalTime: 6, BurstDuration: 1},
+	}
+	// At 6, B has waited 5 for a ratio of 2 while the fresh C has a ratio of 1.
+	wantGantt := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 6},
+		{PID: "B", Start: 6, Stop: 11},
+		{PID: "C", Start: 11, Stop: 12},
+	}
+
+	got := highestResponseRatio(processes)
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+	// A 0, B 5, C 5
+	if want := 10.0 / 3; got.AveWait != want {
+		t.Errorf("AveWait = %v, want %v", got.AveWait, want)
+	}
+
+	// SJF picks the short job instead.
+	if sjf := shortestJobFirst(processes, options{}); sjf.Gantt[1].PID != "C" {
+		t.Errorf("SJF ran %s second, want C", sjf.Gantt[1].PID)
+	}
+}
+
+func Test_earliestDeadline(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 3, Deadline: 10},
+		{ProcessID: "C", ArrivalTime: 1, BurstDuration: 2, Deadline: 4},
+		{ProcessID: "D", ArrivalTime: 2, BurstDuration: 3, Deadline: 5},
+	}
+	wantGantt := []TimeSlice{
+		{PID: "B", Start: 0, Stop: 1},
+		{PID: "C", Start: 1, Stop: 3},
+		{PID: "D", Start: 3, Stop: 6},
+		{PID: "B", Start: 6, Stop: 8},
+		{PID: "A", Start: 8, Stop: 12},
+	}
+
+	got := earliestDeadline(processes)
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+	// Only D misses; A has no deadline to miss.
+	if got.DeadlineMisses != 1 {
+		t.Errorf("DeadlineMisses = %d, want 1", got.DeadlineMisses)
+	}
+}
+
+func TestEDFSchedule_lateness(t *testing.T) {
+	t.Parallel()
+	// FCFS runs the long job first and leaves the urgent ones late, EDF runs them first and is late only with the long one.
+	processes := []Process{
+		{ProcessID: "L", BurstDuration: 6, Deadline: 12},
+		{ProcessID: "U1", ArrivalTime: 1, BurstDuration: 2, Deadline: 3},
+		{ProcessID: "U2", ArrivalTime: 1, BurstDuration: 2, Deadline: 5},
+	}
+	var w bytes.Buffer
+	edf := EDFSchedule(&w, "EDF", processes)
+	if diff := cmp.Diff(Lateness(processes, edf), map[string]int64{"L": -2, "U1": 0, "U2": 0}); diff != "" {
+		t.Errorf(diff)
+	}
+	edfMax, _ := MaxLateness(processes, edf)
+	fcfsMax, _ := MaxLateness(processes, firstComeFirstServe(processes, options{}))
+	if edfMax != 0 || fcfsMax != 5 {
+		t.Errorf("max lateness of EDF %d and FCFS %d, want 0 and 5", edfMax, fcfsMax)
+	}
+	if !strings.HasSuffix(w.String(), "Max lateness: 0\n") || !strings.Contains(w.String(), "Lateness\n") {
+		t.Errorf("lateness not reported:\n%s", w.String())
+	}
+
+	// Without deadlines there is no lateness to report.
+	w.Reset()
+	EDFSchedule(&w, "EDF", []Process{{ProcessID: "P0", BurstDuration: 1}})
+	if strings.Contains(w.String(), "lateness") {
+		t.Errorf("lateness reported without deadlines:\n%s", w.String())
+	}
+	if _, ok := MaxLateness(processes[:0], edf); ok {
+		t.Errorf("max lateness found without processes")
+	}
+
+	// Preemptive EDF minimises maximum lateness on one CPU, so it is never beaten by FCFS.
+	for seed := int64(1); seed <= 20; seed++ {
+		processes := GenerateProcesses(30, seed, GenOptions{})
+		for i := range processes {
+			processes[i].Deadline = processes[i].ArrivalTime + processes[i].BurstDuration + processes[i].Priority
+		}
+		edfMax, _ := MaxLateness(processes, earliestDeadline(processes))
+		fcfsMax, _ := MaxLateness(processes, firstComeFirstServe(processes, options{}))
+		if edfMax > fcfsMax {
+			t.Errorf("seed %d: EDF max lateness %d is more than FCFS %d", seed, edfMax, fcfsMax)
+		}
+	}
+}
+
+func TestWithSwitchCost(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 3},
+	}
+	// A B A B without overhead is 3 switches over 6 units.
+	wantGantt := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 2, Preempted: true},
+		{Start: 2, Stop: 3, Switch: true},
+		{PID: "B", Start: 3, Stop: 5, Preempted: true},
+		{Start: 5, Stop: 6, Switch: true},
+		{PID: "A", Start: 6, Stop: 7},
+		{Start: 7, Stop: 8, Switch: true},
+		{PID: "B", Start: 8, Stop: 9},
+	}
+
+	without := roundRobin(processes, 2, options{})
+	got := roundRobin(processes, 2, newOptions([]Option{WithSwitchCost(1)}))
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+	elapsed := got.Gantt[len(got.Gantt)-1].Stop - without.Gantt[len(without.Gantt)-1].Stop
+	if elapsed != 3 {
+		t.Errorf("elapsed time grew by %d, want 3", elapsed)
+	}
+	if got.Rows[0].Completion != 7 || got.Rows[1].Completion != 9 {
+		t.Errorf("completions = %d, %d, want 7, 9", got.Rows[0].Completion, got.Rows[1].Completion)
+	}
+	// The switches take the CPU but are not busy time.
+	if busy, switching, idle := CPUUsage(got.Gantt); busy != 6 || switching != 3 || idle != 0 {
+		t.Errorf("CPUUsage() = %d, %d, %d, want 6, 3, 0", busy, switching, idle)
+	}
+	if utilization := CPUUtilization(got.Gantt); utilization != 6.0/9 {
+		t.Errorf("CPUUtilization() = %v, want %v", utilization, 6.0/9)
+	}
+
+	// SRTF only switches when the shorter job arrives and when it finishes.
+	srtf := shortestRemainingTime([]Process{
+		{ProcessID: "L", ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: "S", ArrivalTime: 1, BurstDuration: 1},
+	}, newOptions([]Option{WithSwitchCost(2)}))
+	wantSRTF := []TimeSlice{
+		{PID: "L", Start: 0, Stop: 1},
+		{Start: 1, Stop: 3, Switch: true},
+		{PID: "S", Start: 3, Stop: 4},
+		{Start: 4, Stop: 6, Switch: true},
+		{PID: "L", Start: 6, Stop: 10},
+	}
+	if diff := cmp.Diff(srtf.Gantt, wantSRTF); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestWithQuanta(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 10},
+		{ProcessID: "B", BurstDuration: 10},
+	}
+	got := RRSchedule(io.Discard, "Round-robin", 0, processes, WithQuanta(1, 2, 3))
+
+	// A's dispatches run 1, 2, 3, then start over at 1.
+	var runs []int64
+	for _, slice := range got.Gantt {
+		if slice.PID == "A" {
+			runs = append(runs, slice.Stop-slice.Start)
+		}
+	}
+	if diff := cmp.Diff(runs, []int64{1, 2, 3, 1, 2, 1}); diff != "" {
+		t.Errorf(diff)
+	}
+
+	var w bytes.Buffer
+	RRSchedule(&w, "Round-robin", 2, processes, WithQuanta(1, 0))
+	if want := "invalid time quantum 0: must be greater than 0\n"; w.String() != want {
+		t.Errorf("got %q, want %q", w.String(), want)
+	}
+}
+
+func TestWithWarmUp(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 1},
+		{ProcessID: "P2", ArrivalTime: 2, BurstDuration: 2},
+		{ProcessID: "P3", ArrivalTime: 20, BurstDuration: 1},
+	}
+	tests := []struct {
+		name      string
+		schedule  func(processes []Process, o options) ScheduleResult
+		wantGantt []TimeSlice
+	}{
+		{
+			name:     "FCFS",
+			schedule: firstComeFirstServe,
+			wantGantt: []TimeSlice{
+				{PID: "P0", Start: 5, Stop: 8},
+				{PID: "P1", Start: 8, Stop: 9},
+				{PID: "P2", Start: 9, Stop: 11},
+				{PID: "P3", Start: 20, Stop: 21},
+			},
+		},
+		{
+			name:     "SJF",
+			schedule: shortestJobFirst,
+			// All three were waiting at the warm-up, so the shortest goes first.
+			wantGantt: []TimeSlice{
+				{PID: "P1", Start: 5, Stop: 6},
+				{PID: "P2", Start: 6, Stop: 8},
+				{PID: "P0", Start: 8, Stop: 11},
+				{PID: "P3", Start: 20, Stop: 21},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.schedule(processes, newOptions([]Option{WithWarmUp(5)}))
+			if diff := cmp.Diff(got.Gantt, tt.wantGantt); diff != "" {
+				t.Errorf(diff)
+			}
+			for _, row := range got.Rows[:3] {
+				if start := row.ArrivalTime + row.Wait; start < 5 {
+					t.Errorf("%s started at %d, before the warm-up", row.ProcessID, start)
+				}
+			}
+			if got.Rows[3].Wait != 0 {
+				t.Errorf("P3 arrived after the warm-up but waited %d", got.Rows[3].Wait)
+			}
+		})
+	}
+}
+
+func Test_multilevelFeedback(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+	}{
+		{
+			name: "CPU-bound job sinks to the bottom queue",
+			processes: []Process{
+				{ProcessID: "A", BurstDuration: 10},
+			},
+			// 1 at level 0, 2 at level 1, then round-robin in 4s at level 2.
+			wantGantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 1},
+				{PID: "A", Start: 1, Stop: 3},
+				{PID: "A", Start: 3, Stop: 7},
+				{PID: "A", Start: 7, Stop: 10},
+			},
+		},
+		{
+			name: "arrival preempts a lower queue",
+			processes: []Process{
+				{ProcessID: "A", BurstDuration: 10},
+				{ProcessID: "B", ArrivalTime: 5, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 1},
+				{PID: "A", Start: 1, Stop: 3},
+				{PID: "A", Start: 3, Stop: 5},
+				{PID: "B", Start: 5, Stop: 6},
+				{PID: "B", Start: 6, Stop: 7},
+				{PID: "A", Start: 7, Stop: 11},
+				{PID: "A", Start: 11, Stop: 12},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := multilevelFeedback(tt.processes, []int64{1, 2, 4})
+			if diff := cmp.Diff(got.Gantt, tt.wantGantt); diff != "" {
+				t.Errorf(diff)
+			}
+			for i, row := range got.Rows {
+				if row.Wait != row.Turnaround-tt.processes[i].BurstDuration {
+					t.Errorf("%s wait %d does not match turnaround %d", row.ProcessID, row.Wait, row.Turnaround)
+				}
+			}
+		})
+	}
+}
+
+func TestLotterySchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 6, Priority: 1},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 4, Priority: 25},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 3, Priority: 50},
+	}
+
+	var w1, w2 bytes.Buffer
+	first := LotterySchedule(&w1, "Lottery", processes, 42)
+	second := LotterySchedule(&w2, "Lottery", processes, 42)
+	if diff := cmp.Diff(first.Gantt, second.Gantt); diff != "" {
+		t.Errorf("same seed gave different schedules: %s", diff)
+	}
+	if w1.String() != w2.String() {
+		t.Errorf("same seed gave different output")
+	}
+
+	// One slice per quantum, covering every burst exactly.
+	if len(first.Gantt) != 13 {
+		t.Fatalf("got %d slices, want 13", len(first.Gantt))
+	}
+	ran := make(map[string]int64)
+	for i, slice := range first.Gantt {
+		if slice.Start != int64(i) || slice.Stop != int64(i+1) {
+			t.Errorf("slice %d is %+v, want one unit", i, slice)
+		}
+		ran[slice.PID]++
+	}
+	if diff := cmp.Diff(ran, map[string]int64{"A": 6, "B": 4, "C": 3}); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func Test_lotteryTickets(t *testing.T) {
+	t.Parallel()
+	for priority, want := range map[int64]int64{1: 50, 25: 26, 50: 1, 99: 1} {
+		if got := lotteryTickets(Process{Priority: priority}); got != want {
+			t.Errorf("lotteryTickets(priority %d) = %d, want %d", priority, got, want)
+		}
+	}
+}
+
+func TestWithAging(t *testing.T) {
+	t.Parallel()
+	// A high-priority job arrives every unit, so without aging L only runs once the stream dries up.
+	processes := []Process{{ProcessID: "L", BurstDuration: 1, Priority: 10}}
+	for i := 0; i < 50; i++ {
+		processes = append(processes, Process{
+			ProcessID:     fmt.Sprintf("H%d", i),
+			ArrivalTime:   int64(i),
+			BurstDuration: 1,
+			Priority:      1,
+		})
+	}
+
+	if got := preemptivePriority(processes, options{}); got.Rows[0].Completion != 51 {
+		t.Errorf("without aging L completes at %d, want 51", got.Rows[0].Completion)
+	}
+	// Nine boosts, each after 2 units of waiting, lift L to priority 1 at time 18.
+	got := preemptivePriority(processes, newOptions([]Option{WithAging(2)}))
+	if got.Rows[0].Completion != 19 {
+		t.Errorf("with aging L completes at %d, want 19", got.Rows[0].Completion)
+	}
+}
+
+func TestWithPriorityOrder(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 2, Priority: 3},
+		{ProcessID: "P2", ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+	}
+	tests := []struct {
+		name       string
+		preemptive bool
+		order      PriorityOrder
+		want       []string
+	}{
+		{name: "descending", order: DescendingIsHigher, want: []string{"P0", "P2", "P1"}},
+		{name: "ascending", order: AscendingIsHigher, want: []string{"P1", "P2", "P0"}},
+		{name: "preemptive descending", preemptive: true, order: DescendingIsHigher, want: []string{"P0", "P2", "P1"}},
+		{name: "preemptive ascending", preemptive: true, order: AscendingIsHigher, want: []string{"P1", "P2", "P0"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := PrioritySchedule(io.Discard, "Priority", processes, tt.preemptive, WithPriorityOrder(tt.order))
+			var got []string
+			for _, slice := range result.Gantt {
+				got = append(got, slice.PID)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+
+	// Aging lifts a waiting process towards the higher numbers instead, as in TestWithAging.
+	aging := []Process{{ProcessID: "L", BurstDuration: 1, Priority: 1}}
+	for i := 0; i < 50; i++ {
+		aging = append(aging, Process{ProcessID: fmt.Sprintf("H%d", i), ArrivalTime: int64(i), BurstDuration: 1, Priority: 10})
+	}
+	got := preemptivePriority(aging, newOptions([]Option{WithPriorityOrder(AscendingIsHigher), WithAging(2)}))
+	if got.Rows[0].Completion != 19 {
+		t.Errorf("with aging L completes at %d, want 19", got.Rows[0].Completion)
+	}
+}
+
+func Test_firstComeFirstServeMulti(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "P2", ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: "P3", ArrivalTime: 1, BurstDuration: 4},
+	}
+	got := firstComeFirstServeMulti(processes, 2)
+
+	wantGantt := []TimeSlice{
+		{PID: "P0", Start: 0, Stop: 4},
+		{PID: "P1", Start: 0, Stop: 4, CPU: 1},
+		{PID: "P2", Start: 4, Stop: 8},
+		{PID: "P3", Start: 4, Stop: 8, CPU: 1},
+	}
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if got.AveWait != 1.5 || got.AveTurnaround != 5.5 {
+		t.Errorf("averages = %v, %v, want 1.5, 5.5", got.AveWait, got.AveTurnaround)
+	}
+
+	single := firstComeFirstServeMulti(processes, 1)
+	if ratio := got.Throughput / single.Throughput; ratio < 1.9 || ratio > 2.1 {
+		t.Errorf("throughput on 2 CPUs = %v, on 1 CPU = %v, want about double", got.Throughput, single.Throughput)
+	}
+	if busy, switching, idle := CPUUsage(got.Gantt); busy != 16 || switching != 0 || idle != 0 {
+		t.Errorf("CPUUsage() = %d, %d, %d, want 16, 0, 0", busy, switching, idle)
+	}
+}
+
+func TestFCFSScheduleMulti(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	FCFSScheduleMulti(&w, "Multi", []Process{
+		{ProcessID: "P0", BurstDuration: 2},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 2},
+	}, 2)
+	out := w.String()
+	for _, want := range []string{"CPU 0 |     P0      |      |\nCPU 1 |      |     P1      |\n      0      1      2      3\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	w.Reset()
+	FCFSScheduleMulti(&w, "Multi", []Process{{ProcessID: "P0", BurstDuration: 1}}, 0)
+	if diff := cmp.Diff(w.String(), "invalid CPU count 0: must be at least 1\n"); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestWithTieBreak(t *testing.T) {
+	t.Parallel()
+	// All three arrive together with the same burst and priority except where noted.
+	processes := []Process{
+		{ProcessID: "P2", BurstDuration: 2, Priority: 1},
+		{ProcessID: "P3", BurstDuration: 2, Priority: 3},
+		{ProcessID: "P1", BurstDuration: 2, Priority: 2},
+	}
+	samePriority := []Process{
+		{ProcessID: "P2", BurstDuration: 2, Priority: 1},
+		{ProcessID: "P3", BurstDuration: 2, Priority: 1},
+		{ProcessID: "P1", BurstDuration: 2, Priority: 1},
+	}
+	schedulers := map[string]func(processes []Process, o options) ScheduleResult{
+		"FCFS":     firstComeFirstServe,
+		"SJF":      shortestJobFirst,
+		"Priority": highestPriority,
+	}
+	tests := []struct {
+		name      string
+		scheduler string
+		processes []Process
+		tie       TieBreak
+		want      []string
+	}{
+		{name: "FCFS default", scheduler: "FCFS", processes: processes, want: []string{"P2", "P3", "P1"}},
+		{name: "FCFS input order", scheduler: "FCFS", processes: processes, tie: TieByInputOrder, want: []string{"P2", "P3", "P1"}},
+		{name: "FCFS by ID", scheduler: "FCFS", processes: processes, tie: TieByID, want: []string{"P1", "P2", "P3"}},
+		{name: "FCFS by priority", scheduler: "FCFS", processes: processes, tie: TieByPriority, want: []string{"P2", "P1", "P3"}},
+		{name: "SJF default", scheduler: "SJF", processes: processes, want: []string{"P1", "P2", "P3"}},
+		{name: "SJF input order", scheduler: "SJF", processes: processes, tie: TieByInputOrder, want: []string{"P2", "P3", "P1"}},
+		{name: "SJF by ID", scheduler: "SJF", processes: processes, tie: TieByID, want: []string{"P1", "P2", "P3"}},
+		{name: "SJF by priority", scheduler: "SJF", processes: processes, tie: TieByPriority, want: []string{"P2", "P1", "P3"}},
+		{name: "Priority default", scheduler: "Priority", processes: samePriority, want: []string{"P2", "P3", "P1"}},
+		{name: "Priority input order", scheduler: "Priority", processes: samePriority, tie: TieByInputOrder, want: []string{"P2", "P3", "P1"}},
+		{name: "Priority by ID", scheduler: "Priority", processes: samePriority, tie: TieByID, want: []string{"P1", "P2", "P3"}},
+		{name: "Priority by priority falls back to input order", scheduler: "Priority", processes: samePriority, tie: TieByPriority, want: []string{"P2", "P3", "P1"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := schedulers[tt.scheduler](tt.processes, newOptions([]Option{WithTieBreak(tt.tie)}))
+			var order []string
+			for _, slice := range got.Gantt {
+				order = append(order, slice.PID)
+			}
+			if diff := cmp.Diff(order, tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestWeightedFairSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "W1", BurstDuration: 3000, Priority: 1},
+		{ProcessID: "W2", BurstDuration: 3000, Priority: 2},
+		{ProcessID: "W3", BurstDuration: 3000, Priority: 3},
+	}
+	got := WeightedFairSchedule(io.Discard, "Weighted fair", processes)
+
+	// While all three are running, CPU time goes 1:2:3.
+	const window = 1200
+	ran := make(map[string]int64)
+	for _, slice := range got.Gantt {
+		if slice.Start < window {
+			ran[slice.PID] += min(slice.Stop, window) - slice.Start
+		}
+	}
+	for _, p := range processes {
+		want := window * p.Priority / 6
+		if diff := ran[p.ProcessID] - want; diff < -1 || diff > 1 {
+			t.Errorf("%s ran %d of the first %d, want %d", p.ProcessID, ran[p.ProcessID], window, want)
+		}
+	}
+
+	for _, share := range got.FairShares {
+		if share.Actual != 3000 {
+			t.Errorf("%s ran %d, want its whole burst", share.ProcessID, share.Actual)
+		}
+		if d := float64(share.Actual) - share.Fair; d < -1 || d > 1 {
+			t.Errorf("%s fair share %.2f is not within 1 of %d", share.ProcessID, share.Fair, share.Actual)
+		}
+	}
+}
+
+func Test_weightedFair(t *testing.T) {
+	t.Parallel()
+	// B arrives at 2 with the virtual runtime A has reached, so it gets no credit for the time before it arrived.
+	got := weightedFair([]Process{
+		{ProcessID: "A", BurstDuration: 4, Priority: 1},
+		{ProcessID: "B", ArrivalTime: 2, BurstDuration: 2, Priority: 1},
+	})
+	wantGantt := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 3},
+		{PID: "B", Start: 3, Stop: 4},
+		{PID: "A", Start: 4, Stop: 5},
+		{PID: "B", Start: 5, Stop: 6},
+	}
+	if diff := cmp.Diff(got.Gantt, wantGantt); diff != "" {
+		t.Errorf(diff)
+	}
+	wantShares := []FairShare{
+		{ProcessID: "A", Weight: 1, Actual: 4, Fair: 3.5},
+		{ProcessID: "B", Weight: 1, Actual: 2, Fair: 2.5},
+	}
+	if diff := cmp.Diff(got.FairShares, wantShares); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestWithTrace(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		schedule  func(w io.Writer, opts ...Option) ScheduleResult
+		wantTrace string
+	}{
+		{
+			name: "SJF",
+			schedule: func(w io.Writer, opts ...Option) ScheduleResult {
+				return SJFSchedule(w, "Shortest-job-first", []Process{
+					{ProcessID: "X1", ArrivalTime: 0, BurstDuration: 6},
+					{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3},
+					{ProcessID: "A", ArrivalTime: 1, BurstDuration: 1},
+				}, opts...)
+			},
+			wantTrace: "t=0 selected X1 (burst=6)\n" +
+				"t=6 selected A (burst=1) over B (burst=3)\n" +
+				"t=7 selected B (burst=3)\n",
+		},
+		{
+			name: "SRTF",
+			schedule: func(w io.Writer, opts ...Option) ScheduleResult {
+				return SRTFSchedule(w, "Shortest-remaining-time-first", []Process{
+					{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 8},
+					{ProcessID: "P2", ArrivalTime: 1, BurstDuration: 4},
+					{ProcessID: "P3", ArrivalTime: 2, BurstDuration: 9},
+					{ProcessID: "P4", ArrivalTime: 3, BurstDuration: 5},
+				}, opts...)
+			},
+			// P2 keeps the CPU as P3 and P4 arrive, so those decisions are not traced.
+			wantTrace: "t=0 selected P1 (remaining=8)\n" +
+				"t=1 selected P2 (remaining=4) over P1 (remaining=7)\n" +
+				"t=5 selected P4 (remaining=5) over P1 (remaining=7), P3 (remaining=9)\n" +
+				"t=10 selected P1 (remaining=7) over P3 (remaining=9)\n" +
+				"t=17 selected P3 (remaining=9)\n",
+		},
+		{
+			name: "Priority",
+			schedule: func(w io.Writer, opts ...Option) ScheduleResult {
+				return PrioritySchedule(w, "Priority", []Process{
+					{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 2, Priority: 3},
+					{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+					{ProcessID: "P2", ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+				}, false, opts...)
+			},
+			wantTrace: "t=0 selected P0 (priority=3)\n" +
+				"t=2 selected P2 (priority=1) over P1 (priority=2)\n" +
+				"t=4 selected P1 (priority=2)\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var trace bytes.Buffer
+			traced := tt.schedule(io.Discard, WithTrace(&trace))
+			if diff := cmp.Diff(trace.String(), tt.wantTrace); diff != "" {
+				t.Errorf(diff)
+			}
+			// Tracing only observes: the schedule is the same without it.
+			if diff := cmp.Diff(traced, tt.schedule(io.Discard, WithTrace(nil))); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+//region Resumable round-robin
+
+var ErrInvalidState = errors.New("invalid scheduler state")
+
+// SchedulerState is a round-robin run stopped between two dispatches, as RRSimulation.Save returns it.
+// It holds everything the run needs to carry on, so it can be written out as JSON and restored later.
+type SchedulerState struct {
+	Processes  []Process `json:"processes"`
+	Quanta     []int64   `json:"quanta"`
+	SwitchCost int64     `json:"switchCost"`
+	// Time is the current time: when the last dispatch ended.
+	Time int64 `json:"time"`
+	// Remaining is the burst each process has left to run, in the order of Processes.
+	Remaining []int64 `json:"remaining"`
+	// Dispatches is how many times each process has been dispatched, which picks its next quantum.
+	Dispatches []int `json:"dispatches"`
+	// FirstStart is when each process first ran, or -1 if it has not yet.
+	FirstStart []int64 `json:"firstStart"`
+	// Ready is the ready queue as indexes into Processes, head first.
+	Ready []int `json:"ready"`
+	// Arrived is how many processes, counted in arrival order, have been queued so far.
+	Arrived int `json:"arrived"`
+	// Rows has the row of each completed process, and a zero row for the others.
+	Rows  []ScheduleRow `json:"rows"`
+	Done  int           `json:"done"`
+	Gantt []TimeSlice   `json:"gantt"`
+}
+
+// RRSimulation is a round-robin schedule that runs one dispatch at a time, so it can be saved part way through
+// and resumed, even by another program. Run to completion, it gives the same result as RRSchedule.
+// The zero value is ready for Restore.
+type RRSimulation struct {
+	state SchedulerState
+	order []int
+}
+
+// NewRRSimulation returns a round-robin simulation of processes that has not yet started.
+// Processes that ValidateProcesses rejects, or none at all, return an ErrInvalidProcess error,
+// and a quantum that is not greater than 0 an ErrSchedulerParams error.
+// Of the options, WithQuanta and WithSwitchCost apply as they do to RRSchedule.
+// The simulation keeps its own copy of processes, so the caller may reuse the slice.
+func NewRRSimulation(processes []Process, quantum int64, opts ...Option) (*RRSimulation, error) {
+	if len(processes) == 0 {
+		return nil, fmt.Errorf("%w: no processes to schedule", ErrInvalidProcess)
+	}
+	if err := ValidateProcesses(processes); err != nil {
+		return nil, err
+	}
+	o := newOptions(opts)
+	quanta := roundQuanta(quantum, o)
+	for _, q := range quanta {
+		if q <= 0 {
+			return nil, fmt.Errorf("%w: time quantum %d must be greater than 0", ErrSchedulerParams, q)
+		}
+	}
+
+	return newRRSimulation(cloneProcesses(processes), slices.Clone(quanta), o.switchCost), nil
+}
+
+func newRRSimulation(processes []Process, quanta []int64, switchCost int64) *RRSimulation {
+	state := SchedulerState{
+		Processes:  processes,
+		Quanta:     quanta,
+		SwitchCost: switchCost,
+		Remaining:  make([]int64, len(processes)),
+		Dispatches: make([]int, len(processes)),
+		FirstStart: make([]int64, len(processes)),
+		Rows:       make([]ScheduleRow, len(processes)),
+		Gantt:      make([]TimeSlice, 0),
+	}
+	for i := range processes {
+		state.Remaining[i] = processes[i].BurstDuration
+		state.FirstStart[i] = -1
+	}
+
+	return &RRSimulation{state: state, order: arrivalOrder(processes, TieDefault)}
+}
+
+// Done reports whether every process has completed.
+func (s *RRSimulation) Done() bool {
+	return s.state.Done == len(s.state.Processes)
+}
+
+// Step dispatches the process at the head of the ready queue for a quantum, first waiting for the next arrival
+// if the queue is empty. It reports whether there is more to run, and does nothing once Done.
+func (s *RRSimulation) Step() bool {
+	if s.Done() {
+		return false
+	}
+	st := &s.state
+	processes := st.Processes
+
+	s.enqueueArrivals()
+	if len(st.Ready) == 0 {
+		// No available jobs, jump to the next arrival.
+		st.Time = processes[s.order[st.Arrived]].ArrivalTime
+		s.enqueueArrivals()
+	}
+
+	i := st.Ready[0]
+	st.Ready = st.Ready[1:]
+
+	st.Gantt, st.Time = contextSwitch(st.Gantt, processes[i].ProcessID, st.Time, st.SwitchCost)
+
+	run := min(st.Quanta[st.Dispatches[i]%len(st.Quanta)], st.Remaining[i])
+	st.Dispatches[i]++
+	start := st.Time
+	st.Time += run
+	st.Remaining[i] -= run
+	if st.FirstStart[i] < 0 {
+		st.FirstStart[i] = start
+	}
+
+	if last := len(st.Gantt) - 1; last >= 0 && st.Gantt[last].PID == processes[i].ProcessID && st.Gantt[last].Stop == start {
+		// Nothing else was waiting, so the process kept the CPU.
+		st.Gantt[last].Stop = st.Time
+	} else {
+		st.Gantt = append(st.Gantt, TimeSlice{PID: processes[i].ProcessID, Start: start, Stop: st.Time})
+	}
+	st.Gantt[len(st.Gantt)-1].Preempted = st.Remaining[i] > 0
+
+	// Processes arriving during the quantum, or as it expires, are queued before the preempted one.
+	s.enqueueArrivals()
+	if st.Remaining[i] > 0 {
+		st.Ready = append(st.Ready, i)
+		return true
+	}
+	st.Done++
+
+	turnaround := st.Time - processes[i].ArrivalTime
+	st.Rows[i] = ScheduleRow{
+		ProcessID:     processes[i].ProcessID,
+		Priority:      processes[i].Priority,
+		BurstDuration: processes[i].BurstDuration,
+		ArrivalTime:   processes[i].ArrivalTime,
+		Wait:          turnaround - processes[i].BurstDuration,
+		Turnaround:    turnaround,
+		Completion:    st.Time,
+		Response:      st.FirstStart[i] - processes[i].ArrivalTime,
+	}
+
+	return !s.Done()
+}
+
+func (s *RRSimulation) enqueueArrivals() {
+	st := &s.state
+	for st.Arrived < len(s.order) && st.Processes[s.order[st.Arrived]].ArrivalTime <= st.Time {
+		st.Ready = append(st.Ready, s.order[st.Arrived])
+		st.Arrived++
+	}
+}
+
+// Result returns the schedule so far, which is the whole schedule once Done.
+// Processes that have not completed have a zero row.
+func (s *RRSimulation) Result() ScheduleResult {
+	st := s.Save()
+	return newScheduleResult(st.Gantt, st.Rows)
+}
+
+// Save returns a copy of the simulation's state, which later steps do not change.
+func (s *RRSimulation) Save() SchedulerState {
+	return copyState(s.state)
+}
+
+// Restore replaces the simulation's state with a copy of state, so that it carries on from where state was saved.
+// A state whose fields do not agree, such as per-process fields of different lengths or a ready process with nothing
+// left to run, returns an ErrInvalidState error and leaves the simulation as it was.
+func (s *RRSimulation) Restore(state SchedulerState) error {
+	n := len(state.Processes)
+	switch {
+	case len(state.Remaining) != n || len(state.Dispatches) != n || len(state.FirstStart) != n || len(state.Rows) != n:
+		return fmt.Errorf("%w: %d processes but per-process fields of other lengths", ErrInvalidState, n)
+	case len(state.Quanta) == 0:
+		return fmt.Errorf("%w: no quanta", ErrInvalidState)
+	case state.Arrived < 0 || state.Arrived > n || state.Done < 0 || state.Done > n:
+		return fmt.Errorf("%w: %d arrived and %d done out of %d processes", ErrInvalidState, state.Arrived, state.Done, n)
+	}
+	for _, q := range state.Quanta {
+		if q <= 0 {
+			return fmt.Errorf("%w: time quantum %d is not greater than 0", ErrInvalidState, q)
+		}
+	}
+	for _, i := range state.Ready {
+		if i < 0 || i >= n || state.Remaining[i] <= 0 {
+			return fmt.Errorf("%w: ready process %d has nothing to run", ErrInvalidState, i)
+		}
+	}
+	if state.Done < n && len(state.Ready) == 0 && state.Arrived == n {
+		return fmt.Errorf("%w: %d processes left but none ready or to arrive", ErrInvalidState, n-state.Done)
+	}
+
+	s.state = copyState(state)
+	s.order = arrivalOrder(s.state.Processes, TieDefault)
+
+	return nil
+}
+
+// copyState is a deep copy of state.
+func copyState(state SchedulerState) SchedulerState {
+	state.Processes = cloneProcesses(state.Processes)
+	state.Quanta = append([]int64(nil), state.Quanta...)
+	state.Remaining = append([]int64(nil), state.Remaining...)
+	state.Dispatches = append([]int(nil), state.Dispatches...)
+	state.FirstStart = append([]int64(nil), state.FirstStart...)
+	state.Ready = append([]int(nil), state.Ready...)
+	state.Rows = append([]ScheduleRow(nil), state.Rows...)
+	state.Gantt = append(make([]TimeSlice, 0, len(state.Gantt)), state.Gantt...)
+
+	return state
+}
+
+//endregion
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRRSimulation_resume(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		quantum   int64
+		opts      []Option
+	}{
+		{name: "random", processes: GenerateProcesses(40, 3, GenOptions{}), quantum: 4},
+		{name: "idle gaps", processes: GenerateProcesses(20, 4, GenOptions{MaxArrival: 500}), quantum: 2},
+		{name: "quanta and switch cost", processes: GenerateProcesses(30, 5, GenOptions{}), opts: []Option{WithQuanta(1, 3), WithSwitchCost(1)}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			want := roundRobin(tt.processes, tt.quantum, newOptions(tt.opts))
+
+			sim, err := NewRRSimulation(tt.processes, tt.quantum, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for steps := 0; steps < len(want.Gantt)/2; steps++ {
+				sim.Step()
+			}
+			if sim.Done() {
+				t.Fatalf("done halfway")
+			}
+
+			// The state survives a trip through JSON into a fresh simulation.
+			b, err := json.Marshal(sim.Save())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var state SchedulerState
+			if err := json.Unmarshal(b, &state); err != nil {
+				t.Fatal(err)
+			}
+			var resumed RRSimulation
+			if err := resumed.Restore(state); err != nil {
+				t.Fatal(err)
+			}
+			for resumed.Step() {
+			}
+			if diff := cmp.Diff(resumed.Result(), want); diff != "" {
+				t.Errorf(diff)
+			}
+
+			// Steps after the save do not reach the saved state.
+			saved := sim.Save()
+			sim.Step()
+			if cmp.Equal(saved, sim.Save()) {
+				t.Errorf("Step() did not change the state")
+			}
+			if err := sim.Restore(saved); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(sim.Save(), saved); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestRRSimulation_saveBursts(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", BurstDuration: 3, Bursts: []BurstSegment{{Kind: CPUBurst, Duration: 3}}},
+		{ProcessID: "P1", BurstDuration: 2},
+	}
+	sim, err := NewRRSimulation(processes, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Neither a saved state nor one restored from shares its Bursts with the simulation.
+	saved := sim.Save()
+	saved.Processes[0].Bursts[0].Duration = 100
+	if got := sim.Save().Processes[0].Bursts[0].Duration; got != 3 {
+		t.Errorf("changing a saved state's bursts changed the simulation's to %d", got)
+	}
+	state := sim.Save()
+	if err := sim.Restore(state); err != nil {
+		t.Fatal(err)
+	}
+	state.Processes[0].Bursts[0].Duration = 100
+	if got := sim.Save().Processes[0].Bursts[0].Duration; got != 3 {
+		t.Errorf("changing a restored state's bursts changed the simulation's to %d", got)
+	}
+}
+
+func TestRRSimulation_invalid(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "P0", BurstDuration: 3}, {ProcessID: "P1", BurstDuration: 2}}
+	if _, err := NewRRSimulation(processes, 0); !errors.Is(err, ErrSchedulerParams) {
+		t.Errorf("NewRRSimulation() with quantum 0 error = %v, want %v", err, ErrSchedulerParams)
+	}
+	if _, err := NewRRSimulation(nil, 1); !errors.Is(err, ErrInvalidProcess) {
+		t.Errorf("NewRRSimulation(nil) error = %v, want %v", err, ErrInvalidProcess)
+	}
+
+	sim, err := NewRRSimulation(processes, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Step()
+	good := sim.Save()
+	tests := []struct {
+		name   string
+		change func(*SchedulerState)
+	}{
+		{name: "short remaining", change: func(s *SchedulerState) { s.Remaining = s.Remaining[:1] }},
+		{name: "no quanta", change: func(s *SchedulerState) { s.Quanta = nil }},
+		{name: "ready out of range", change: func(s *SchedulerState) { s.Ready = append(s.Ready, 2) }},
+		{name: "nothing left to run", change: func(s *SchedulerState) { s.Ready = nil }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			state := copyState(good)
+			tt.change(&state)
+			var fresh RRSimulation
+			if err := fresh.Restore(state); !errors.Is(err, ErrInvalidState) {
+				t.Errorf("Restore() error = %v, want %v", err, ErrInvalidState)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+//region SVG output
+
+// SVGOptions sizes the chart WriteGanttSVG draws. Zero values take the defaults.
+type SVGOptions struct {
+	// Width is the pixel width of the time axis, 800 by default.
+	Width int
+	// RowHeight is the pixel height of each CPU's row of blocks, 40 by default.
+	RowHeight int
+}
+
+const (
+	svgMargin     = 20
+	svgAxisHeight = 30
+)
+
+// svgPalette is cycled through to colour processes in the order they first run.
+var svgPalette = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"}
+
+// WriteGanttSVG writes gantt to w as an SVG image: a labelled rectangle per slice, coloured by PID, on an axis with a tick
+// at every boundary. Idle time is a hatched rectangle and context switches are grey. A chart across several CPUs
+// has a row per CPU.
+func WriteGanttSVG(w io.Writer, gantt []TimeSlice, opts SVGOptions) error {
+	if opts.Width <= 0 {
+		opts.Width = 800
+	}
+	if opts.RowHeight <= 0 {
+		opts.RowHeight = 40
+	}
+
+	var end int64
+	for _, slice := range gantt {
+		if slice.Stop > end {
+			end = slice.Stop
+		}
+	}
+	x := func(t int64) float64 {
+		if end == 0 {
+			return svgMargin
+		}
+		return svgMargin + float64(t)*float64(opts.Width)/float64(end)
+	}
+
+	cpus := cpuCount(gantt)
+	rows := make([][]TimeSlice, cpus)
+	for _, slice := range gantt {
+		rows[slice.CPU] = append(rows[slice.CPU], slice)
+	}
+	height := svgMargin*2 + cpus*opts.RowHeight + svgAxisHeight
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"sans-serif\" font-size=\"12\">\n",
+		opts.Width+svgMargin*2, height)
+	b.WriteString("<defs><pattern id=\"idle\" width=\"6\" height=\"6\" patternUnits=\"userSpaceOnUse\" patternTransform=\"rotate(45)\">" +
+		"<line x1=\"0\" y1=\"0\" x2=\"0\" y2=\"6\" stroke=\"#999\" stroke-width=\"2\"/></pattern></defs>\n")
+
+	colours := make(map[string]string)
+	boundaries := make(map[int64]bool)
+	for cpu, row := range rows {
+		top := svgMargin + cpu*opts.RowHeight
+		for _, block := range ganttBlocks(row) {
+			boundaries[block.Start] = true
+			boundaries[block.Stop] = true
+
+			fill := "url(#idle)"
+			label := ""
+			switch {
+			case block.Switch:
+				fill = "#cccccc"
+				label = switchLabel
+			case !block.Idle:
+				if _, ok := colours[block.PID]; !ok {
+					colours[block.PID] = svgPalette[len(colours)%len(svgPalette)]
+				}
+				fill = colours[block.PID]
+				label = block.PID
+			}
+			_, _ = fmt.Fprintf(&b, "<rect x=\"%.2f\" y=\"%d\" width=\"%.2f\" height=\"%d\" fill=\"%s\" stroke=\"#333\"/>\n",
+				x(block.Start), top, x(block.Stop)-x(block.Start), opts.RowHeight, fill)
+			if label != "" {
+				_, _ = fmt.Fprintf(&b, "<text x=\"%.2f\" y=\"%d\" text-anchor=\"middle\" dominant-baseline=\"middle\">",
+					(x(block.Start)+x(block.Stop))/2, top+opts.RowHeight/2)
+				_ = xml.EscapeText(&b, []byte(label))
+				b.WriteString("</text>\n")
+			}
+		}
+	}
+
+	baseline := svgMargin + cpus*opts.RowHeight
+	_, _ = fmt.Fprintf(&b, "<line x1=\"%.2f\" y1=\"%d\" x2=\"%.2f\" y2=\"%d\" stroke=\"#333\"/>\n", x(0), baseline, x(end), baseline)
+	ticks := make([]int64, 0, len(boundaries))
+	for t := range boundaries {
+		ticks = append(ticks, t)
+	}
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i] < ticks[j] })
+	for _, t := range ticks {
+		_, _ = fmt.Fprintf(&b, "<line x1=\"%.2f\" y1=\"%d\" x2=\"%.2f\" y2=\"%d\" stroke=\"#333\"/>\n", x(t), baseline, x(t), baseline+5)
+		_, _ = fmt.Fprintf(&b, "<text x=\"%.2f\" y=\"%d\" text-anchor=\"middle\">%d</text>\n", x(t), baseline+18, t)
+	}
+	b.WriteString("</svg>\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("%w: writing gantt SVG", err)
+	}
+
+	return nil
+}
+
+//endregion
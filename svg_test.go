@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestWriteGanttSVG(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		gantt     []TimeSlice
+		wantRects int
+		wantTexts int
+	}{
+		{
+			name: "idle gap",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: "P<1>", Start: 5, Stop: 6},
+			},
+			// P0, idle, and P<1>, with ticks at 0, 2, 5, and 6.
+			wantRects: 3,
+			wantTexts: 2 + 4,
+		},
+		{
+			name: "process named idle",
+			gantt: []TimeSlice{
+				{PID: "idle", Start: 0, Stop: 2},
+				{PID: "P1", Start: 5, Stop: 6},
+			},
+			// The process is labelled, unlike the idle gap after it.
+			wantRects: 3,
+			wantTexts: 2 + 4,
+		},
+		{
+			name: "two CPUs",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 4},
+				{PID: "P1", Start: 1, Stop: 4, CPU: 1},
+			},
+			wantRects: 3,
+			wantTexts: 2 + 3,
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := WriteGanttSVG(&w, tt.gantt, SVGOptions{Width: 300}); err != nil {
+				t.Fatal(err)
+			}
+
+			var rects, texts int
+			dec := xml.NewDecoder(&w)
+			for {
+				token, err := dec.Token()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("invalid XML: %v", err)
+				}
+				if start, ok := token.(xml.StartElement); ok {
+					switch start.Name.Local {
+					case "rect":
+						rects++
+					case "text":
+						texts++
+					}
+				}
+			}
+			if rects != tt.wantRects || texts != tt.wantTexts {
+				t.Errorf("got %d rects and %d texts, want %d and %d", rects, texts, tt.wantRects, tt.wantTexts)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+)
+
+//region Validation
+
+var (
+	ErrInvalidProcess = errors.New("invalid process")
+	ErrOverflow       = errors.New("time overflow")
+	ErrInvalidGantt   = errors.New("invalid gantt")
+)
+
+// ValidateProcesses returns an ErrInvalidProcess error naming the first process that cannot be scheduled:
+// a zero or negative burst, a negative arrival time or deadline, a ProcessID used more than once,
+// or burst segments that are not positive or do not add up to BurstDuration.
+// It returns an ErrOverflow error if the processes would take longer than an int64 can count.
+func ValidateProcesses(processes []Process) error {
+	return validateProcesses(processes, false)
+}
+
+// validateProcesses is ValidateProcesses that lets zero bursts through if zeroBursts is set.
+func validateProcesses(processes []Process, zeroBursts bool) error {
+	seen := make(map[string]bool, len(processes))
+	for _, p := range processes {
+		switch {
+		case p.BurstDuration == 0 && !zeroBursts:
+			return fmt.Errorf("%w: %q has a zero burst duration and would never run", ErrInvalidProcess, p.ProcessID)
+		case p.BurstDuration < 0:
+			return fmt.Errorf("%w: %q has a negative burst duration %d", ErrInvalidProcess, p.ProcessID, p.BurstDuration)
+		case p.ArrivalTime < 0:
+			return fmt.Errorf("%w: %q has a negative arrival time %d", ErrInvalidProcess, p.ProcessID, p.ArrivalTime)
+		case p.Deadline < 0:
+			return fmt.Errorf("%w: %q has a negative deadline %d", ErrInvalidProcess, p.ProcessID, p.Deadline)
+		case seen[p.ProcessID]:
+			return fmt.Errorf("%w: %q is used by more than one process", ErrInvalidProcess, p.ProcessID)
+		}
+		if err := validateBursts(p); err != nil {
+			return err
+		}
+		seen[p.ProcessID] = true
+	}
+
+	return checkScheduleLength(processes)
+}
+
+// checkScheduleLength returns an ErrOverflow error if the latest arrival or NotBefore plus every burst and I/O segment
+// does not fit in an int64. No schedule without switch costs runs past that total,
+// so once it fits the service time of FCFS, SJF, and the others cannot wrap around.
+func checkScheduleLength(processes []Process) error {
+	var latestArrival, total int64
+	for _, p := range processes {
+		latestArrival = max(latestArrival, eligibleAt(p))
+		for _, segment := range segments(p) {
+			var ok bool
+			if total, ok = checkedAdd(total, segment.Duration); !ok {
+				return fmt.Errorf("%w: the bursts total more than %d", ErrOverflow, int64(math.MaxInt64))
+			}
+		}
+	}
+	if _, ok := checkedAdd(latestArrival, total); !ok {
+		return fmt.Errorf("%w: the schedule would run past %d", ErrOverflow, int64(math.MaxInt64))
+	}
+
+	return nil
+}
+
+// checkedAdd returns a+b for non-negative a and b, and whether it fit in an int64.
+func checkedAdd(a, b int64) (int64, bool) {
+	if a > math.MaxInt64-b {
+		return 0, false
+	}
+	return a + b, true
+}
+
+func validateBursts(p Process) error {
+	if len(p.Bursts) == 0 {
+		return nil
+	}
+	var cpu int64
+	for _, segment := range p.Bursts {
+		if segment.Duration <= 0 {
+			return fmt.Errorf("%w: %q has a non-positive %v segment duration %d", ErrInvalidProcess, p.ProcessID, segment.Kind, segment.Duration)
+		}
+		if segment.Kind == CPUBurst {
+			cpu += segment.Duration
+		}
+	}
+	if cpu != p.BurstDuration {
+		return fmt.Errorf("%w: %q has a burst duration %d but its CPU segments total %d", ErrInvalidProcess, p.ProcessID, p.BurstDuration, cpu)
+	}
+
+	return nil
+}
+
+// ValidateGantt returns an ErrInvalidGantt error naming the first slice of gantt no scheduler should produce:
+// one on a negative CPU, one that does not stop after it starts, or one that starts before the slice ahead of it
+// on the same CPU has stopped. Slices on different CPUs may overlap.
+func ValidateGantt(gantt []TimeSlice) error {
+	stops := make(map[int]int64)
+	for i, slice := range gantt {
+		stop, ok := stops[slice.CPU]
+		switch {
+		case slice.CPU < 0:
+			return fmt.Errorf("%w: slice %d %v is on a negative CPU", ErrInvalidGantt, i, slice)
+		case slice.Stop <= slice.Start:
+			return fmt.Errorf("%w: slice %d %v does not stop after it starts", ErrInvalidGantt, i, slice)
+		case ok && slice.Start < stop:
+			return fmt.Errorf("%w: slice %d %v starts before the slice ahead of it stops at %d", ErrInvalidGantt, i, slice, stop)
+		}
+		stops[slice.CPU] = slice.Stop
+	}
+
+	return nil
+}
+
+// ValidateGanttBursts is ValidateGantt that also checks gantt is a schedule of processes:
+// every slice runs one of them, none before it is eligible to run, and each runs for exactly its BurstDuration.
+func ValidateGanttBursts(gantt []TimeSlice, processes []Process) error {
+	if err := ValidateGantt(gantt); err != nil {
+		return err
+	}
+
+	byID := make(map[string]Process, len(processes))
+	for _, p := range processes {
+		byID[p.ProcessID] = p
+	}
+	ran := make(map[string]int64, len(processes))
+	for i, slice := range gantt {
+		if slice.Switch {
+			continue
+		}
+		p, ok := byID[slice.PID]
+		switch {
+		case !ok:
+			return fmt.Errorf("%w: slice %d %v runs a process that is not scheduled", ErrInvalidGantt, i, slice)
+		case slice.Start < eligibleAt(p):
+			return fmt.Errorf("%w: slice %d %v runs %q before it can run at %d", ErrInvalidGantt, i, slice, p.ProcessID, eligibleAt(p))
+		}
+		ran[slice.PID] += slice.Stop - slice.Start
+	}
+	for _, p := range processes {
+		if ran[p.ProcessID] != p.BurstDuration {
+			return fmt.Errorf("%w: %q runs for %d but has a burst duration %d", ErrInvalidGantt, p.ProcessID, ran[p.ProcessID], p.BurstDuration)
+		}
+	}
+
+	return nil
+}
+
+// Severity ranks how much a Diagnostic matters.
+type Severity int
+
+const (
+	// SeverityInfo notes something about the input that is not a problem.
+	SeverityInfo Severity = iota
+	// SeverityWarning is a schedulable input that is probably not what was meant.
+	SeverityWarning
+	// SeverityError is a problem ValidateProcesses rejects.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Diagnostic is one finding of DiagnoseProcesses.
+type Diagnostic struct {
+	Severity Severity
+	// ProcessID is the process the finding is about, or empty if it is about the input as a whole.
+	ProcessID string
+	Message   string
+}
+
+func (d Diagnostic) String() string {
+	if d.ProcessID == "" {
+		return fmt.Sprintf("%v: %s", d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%v: %q %s", d.Severity, d.ProcessID, d.Message)
+}
+
+// DiagnoseProcesses reports everything worth knowing about processes before scheduling them, without scheduling them.
+// Unlike ValidateProcesses it does not stop at the first problem: every process ValidateProcesses would reject is an error,
+// idle gaps where no process has arrived are warnings, and input not sorted by arrival time is noted.
+// It returns nil when there is nothing to report.
+func DiagnoseProcesses(processes []Process) []Diagnostic {
+	var diagnostics []Diagnostic
+	report := func(severity Severity, id, format string, args ...any) {
+		diagnostics = append(diagnostics, Diagnostic{Severity: severity, ProcessID: id, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(processes) == 0 {
+		report(SeverityWarning, "", "there are no processes to schedule")
+		return diagnostics
+	}
+
+	seen := make(map[string]bool, len(processes))
+	runnable := make([]Process, 0, len(processes))
+	for _, p := range processes {
+		ok := true
+		switch {
+		case p.BurstDuration == 0:
+			report(SeverityError, p.ProcessID, "has a zero burst duration and would never run")
+			ok = false
+		case p.BurstDuration < 0:
+			report(SeverityError, p.ProcessID, "has a negative burst duration %d", p.BurstDuration)
+			ok = false
+		}
+		if p.ArrivalTime < 0 {
+			report(SeverityError, p.ProcessID, "has a negative arrival time %d", p.ArrivalTime)
+			ok = false
+		}
+		if p.Deadline < 0 {
+			report(SeverityError, p.ProcessID, "has a negative deadline %d", p.Deadline)
+		}
+		if seen[p.ProcessID] {
+			report(SeverityError, p.ProcessID, "is used by more than one process")
+		}
+		if ok {
+			if err := validateBursts(p); err != nil {
+				report(SeverityError, p.ProcessID, "has burst segments that do not fit its burst duration")
+			}
+		}
+		seen[p.ProcessID] = true
+		if ok {
+			runnable = append(runnable, p)
+		}
+	}
+	if err := checkScheduleLength(runnable); err != nil {
+		report(SeverityError, "", "%v", err)
+		return diagnostics
+	}
+
+	for i := 1; i < len(processes); i++ {
+		if processes[i].ArrivalTime < processes[i-1].ArrivalTime {
+			report(SeverityInfo, processes[i].ProcessID, "arrives before the process listed ahead of it, so the input is not sorted by arrival time")
+			break
+		}
+	}
+
+	// Whatever the algorithm, the CPU can only be idle while every process that has arrived is done.
+	var busyUntil int64
+	for _, i := range arrivalOrder(runnable, TieDefault) {
+		p := runnable[i]
+		if p.ArrivalTime > busyUntil {
+			report(SeverityWarning, p.ProcessID, "arrives at %d, leaving the CPU idle from %d", p.ArrivalTime, busyUntil)
+			busyUntil = p.ArrivalTime
+		}
+		busyUntil += p.BurstDuration
+	}
+
+	return diagnostics
+}
+
+// schedulable writes why processes cannot be scheduled to w, reporting whether scheduling should go ahead.
+// An empty slice is not an error, but there is nothing to schedule or average.
+func schedulable(w io.Writer, processes []Process) bool {
+	if len(processes) == 0 {
+		_, _ = fmt.Fprintln(w, "no processes to schedule")
+		return false
+	}
+	if err := ValidateProcesses(processes); err != nil {
+		_, _ = fmt.Fprintln(w, err)
+		return false
+	}
+
+	return true
+}
+
+// cloneProcesses returns a copy of processes that shares no memory with it, down to their burst segments.
+// Every scheduler works on one, so that however it orders or updates its processes the caller's slice is left as it was.
+func cloneProcesses(processes []Process) []Process {
+	clones := make([]Process, len(processes))
+	for i, p := range processes {
+		clones[i] = p
+		clones[i].Bursts = slices.Clone(p.Bursts)
+	}
+
+	return clones
+}
+
+//endregion
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   string
+		wantIs    error
+	}{
+		{
+			name: "valid",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 5},
+				{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Deadline: 20},
+			},
+		},
+		{
+			name: "empty",
+		},
+		{
+			name:      "zero burst",
+			processes: []Process{{ProcessID: "P0", BurstDuration: 0}},
+			wantErr:   `invalid process: "P0" has a zero burst duration and would never run`,
+		},
+		{
+			name:      "negative burst",
+			processes: []Process{{ProcessID: "P0", BurstDuration: -3}},
+			wantErr:   `invalid process: "P0" has a negative burst duration -3`,
+		},
+		{
+			name: "burst segments",
+			processes: []Process{{ProcessID: "P0", BurstDuration: 3, Bursts: []BurstSegment{
+				{Kind: CPUBurst, Duration: 1}, {Kind: IOBurst, Duration: 5}, {Kind: CPUBurst, Duration: 2},
+			}}},
+		},
+		{
+			name: "burst segments not matching the burst",
+			processes: []Process{{ProcessID: "P0", BurstDuration: 4, Bursts: []BurstSegment{
+				{Kind: CPUBurst, Duration: 1}, {Kind: IOBurst, Duration: 5},
+			}}},
+			wantErr: `invalid process: "P0" has a burst duration 4 but its CPU segments total 1`,
+		},
+		{
+			name: "empty I/O segment",
+			processes: []Process{{ProcessID: "P0", BurstDuration: 1, Bursts: []BurstSegment{
+				{Kind: CPUBurst, Duration: 1}, {Kind: IOBurst},
+			}}},
+			wantErr: `invalid process: "P0" has a non-positive I/O segment duration 0`,
+		},
+		{
+			name: "bursts overflow",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: math.MaxInt64 - 1},
+				{ProcessID: "P1", BurstDuration: 2},
+			},
+			wantErr: "time overflow: the bursts total more than 9223372036854775807",
+			wantIs:  ErrOverflow,
+		},
+		{
+			name: "late arrival overflows",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: math.MaxInt64 - 1, BurstDuration: 2},
+			},
+			wantErr: "time overflow: the schedule would run past 9223372036854775807",
+			wantIs:  ErrOverflow,
+		},
+		{
+			name: "fits exactly",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: math.MaxInt64 - 2, BurstDuration: 1},
+				{ProcessID: "P1", BurstDuration: 1},
+			},
+		},
+		{
+			name:      "negative arrival",
+			processes: []Process{{ProcessID: "P0", BurstDuration: 1}, {ProcessID: "P1", ArrivalTime: -1, BurstDuration: 1}},
+			wantErr:   `invalid process: "P1" has a negative arrival time -1`,
+		},
+		{
+			name:      "negative deadline",
+			processes: []Process{{ProcessID: "P0", BurstDuration: 1, Deadline: -5}},
+			wantErr:   `invalid process: "P0" has a negative deadline -5`,
+		},
+		{
+			name:      "duplicate ID",
+			processes: []Process{{ProcessID: "P0", BurstDuration: 1}, {ProcessID: "P0", BurstDuration: 2}},
+			wantErr:   `invalid process: "P0" is used by more than one process`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateProcesses(tt.processes)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			wantIs := tt.wantIs
+			if wantIs == nil {
+				wantIs = ErrInvalidProcess
+			}
+			if !errors.Is(err, wantIs) || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateGantt(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", BurstDuration: 4},
+		{ProcessID: "P1", ArrivalTime: 2, BurstDuration: 3},
+	}
+	tests := []struct {
+		name    string
+		gantt   []TimeSlice
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			gantt: []TimeSlice{{PID: "P0", Stop: 4}, {Start: 4, Stop: 5, Switch: true}, {PID: "P1", Start: 5, Stop: 8}},
+		},
+		{
+			name:  "overlaps on other CPUs",
+			gantt: []TimeSlice{{PID: "P0", Stop: 4}, {PID: "P1", Start: 2, Stop: 5, CPU: 1}},
+		},
+		{
+			name:    "overlapping",
+			gantt:   []TimeSlice{{PID: "P0", Stop: 4}, {PID: "P1", Start: 3, Stop: 6}},
+			wantErr: "invalid gantt: slice 1 P1 [3,6) starts before the slice ahead of it stops at 4",
+		},
+		{
+			name:    "out of order",
+			gantt:   []TimeSlice{{PID: "P1", Start: 4, Stop: 7}, {PID: "P0", Stop: 4}},
+			wantErr: "invalid gantt: slice 1 P0 [0,4) starts before the slice ahead of it stops at 7",
+		},
+		{
+			name:    "empty slice",
+			gantt:   []TimeSlice{{PID: "P0", Start: 4, Stop: 4}},
+			wantErr: "invalid gantt: slice 0 P0 [4,4) does not stop after it starts",
+		},
+		{
+			name:    "negative CPU",
+			gantt:   []TimeSlice{{PID: "P0", Stop: 4, CPU: -1}},
+			wantErr: "invalid gantt: slice 0 P0 [0,4) on CPU -1 is on a negative CPU",
+		},
+		{
+			name:    "unknown process",
+			gantt:   []TimeSlice{{PID: "P0", Stop: 4}, {PID: "P9", Start: 4, Stop: 7}},
+			wantErr: "invalid gantt: slice 1 P9 [4,7) runs a process that is not scheduled",
+		},
+		{
+			name:    "runs before arrival",
+			gantt:   []TimeSlice{{PID: "P1", Start: 1, Stop: 4}, {PID: "P0", Start: 4, Stop: 8}},
+			wantErr: `invalid gantt: slice 0 P1 [1,4) runs "P1" before it can run at 2`,
+		},
+		{
+			name:    "short of the burst",
+			gantt:   []TimeSlice{{PID: "P0", Stop: 4}, {PID: "P1", Start: 4, Stop: 6}},
+			wantErr: `invalid gantt: "P1" runs for 2 but has a burst duration 3`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateGanttBursts(tt.gantt, processes)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidGantt) || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiagnoseProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []Diagnostic
+	}{
+		{
+			name: "clean",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 5},
+				{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9},
+			},
+		},
+		{
+			name: "empty",
+			want: []Diagnostic{{Severity: SeverityWarning, Message: "there are no processes to schedule"}},
+		},
+		{
+			name: "crafted",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: 2, BurstDuration: 3},
+				{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 0},
+				{ProcessID: "P0", ArrivalTime: 10, BurstDuration: 1},
+				{ProcessID: "P2", ArrivalTime: -1, BurstDuration: 4},
+				{ProcessID: "P3", ArrivalTime: 4, BurstDuration: 2, Bursts: []BurstSegment{{Kind: CPUBurst, Duration: 1}}},
+			},
+			want: []Diagnostic{
+				{Severity: SeverityError, ProcessID: "P1", Message: "has a zero burst duration and would never run"},
+				{Severity: SeverityError, ProcessID: "P0", Message: "is used by more than one process"},
+				{Severity: SeverityError, ProcessID: "P2", Message: "has a negative arrival time -1"},
+				{Severity: SeverityError, ProcessID: "P3", Message: "has burst segments that do not fit its burst duration"},
+				{Severity: SeverityInfo, ProcessID: "P1", Message: "arrives before the process listed ahead of it, so the input is not sorted by arrival time"},
+				{Severity: SeverityWarning, ProcessID: "P0", Message: "arrives at 2, leaving the CPU idle from 0"},
+				{Severity: SeverityWarning, ProcessID: "P0", Message: "arrives at 10, leaving the CPU idle from 7"},
+			},
+		},
+		{
+			name: "overflow",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: math.MaxInt64},
+				{ProcessID: "P1", BurstDuration: 1},
+			},
+			want: []Diagnostic{
+				{Severity: SeverityError, Message: "time overflow: the bursts total more than 9223372036854775807"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(DiagnoseProcesses(tt.processes), tt.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestDiagnostic_String(t *testing.T) {
+	t.Parallel()
+	for d, want := range map[Diagnostic]string{
+		{Severity: SeverityError, ProcessID: "P1", Message: "has a zero burst duration and would never run"}: `error: "P1" has a zero burst duration and would never run`,
+		{Severity: SeverityWarning, Message: "there are no processes to schedule"}:                           "warning: there are no processes to schedule",
+	} {
+		if got := d.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestSchedulersRejectInvalidProcesses(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "P0", BurstDuration: -1}}
+	want := "invalid process: \"P0\" has a negative burst duration -1\n"
+	schedulers := map[string]func(w *bytes.Buffer) ScheduleResult{
+		"FCFS":     func(w *bytes.Buffer) ScheduleResult { return FCFSSchedule(w, "t", processes) },
+		"SJF":      func(w *bytes.Buffer) ScheduleResult { return SJFSchedule(w, "t", processes) },
+		"SRTF":     func(w *bytes.Buffer) ScheduleResult { return SRTFSchedule(w, "t", processes) },
+		"Priority": func(w *bytes.Buffer) ScheduleResult { return PrioritySchedule(w, "t", processes, false) },
+		"RR":       func(w *bytes.Buffer) ScheduleResult { return RRSchedule(w, "t", 1, processes) },
+	}
+	for name, schedule := range schedulers {
+		schedule := schedule
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			got := schedule(&w)
+			if diff := cmp.Diff(w.String(), want); diff != "" {
+				t.Errorf(diff)
+			}
+			if len(got.Rows) != 0 {
+				t.Errorf("unexpected rows %v", got.Rows)
+			}
+		})
+	}
+}
+
+func TestSchedulersRejectOverflow(t *testing.T) {
+	t.Parallel()
+	// Either burst alone fits, but P1 would finish past math.MaxInt64 and wrap around to a negative time.
+	processes := []Process{
+		{ProcessID: "P0", BurstDuration: math.MaxInt64 - 10},
+		{ProcessID: "P1", BurstDuration: 20},
+	}
+	want := "time overflow: the bursts total more than 9223372036854775807\n"
+	schedulers := map[string]func(w *bytes.Buffer) ScheduleResult{
+		"FCFS": func(w *bytes.Buffer) ScheduleResult { return FCFSSchedule(w, "t", processes) },
+		"SJF":  func(w *bytes.Buffer) ScheduleResult { return SJFSchedule(w, "t", processes) },
+	}
+	for name, schedule := range schedulers {
+		schedule := schedule
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			got := schedule(&w)
+			if diff := cmp.Diff(w.String(), want); diff != "" {
+				t.Errorf(diff)
+			}
+			if len(got.Rows) != 0 {
+				t.Errorf("unexpected rows %v", got.Rows)
+			}
+		})
+	}
+}
+
+func TestSchedulersEmptyProcesses(t *testing.T) {
+	t.Parallel()
+	schedulers := map[string]func(w *bytes.Buffer) ScheduleResult{
+		"FCFS":               func(w *bytes.Buffer) ScheduleResult { return FCFSSchedule(w, "t", nil) },
+		"SJF":                func(w *bytes.Buffer) ScheduleResult { return SJFSchedule(w, "t", nil) },
+		"SJFPriority":        func(w *bytes.Buffer) ScheduleResult { return SJFPrioritySchedule(w, "t", nil) },
+		"Priority":           func(w *bytes.Buffer) ScheduleResult { return PrioritySchedule(w, "t", nil, false) },
+		"HRRN":               func(w *bytes.Buffer) ScheduleResult { return HRRNSchedule(w, "t", nil) },
+		"SRTF":               func(w *bytes.Buffer) ScheduleResult { return SRTFSchedule(w, "t", nil) },
+		"PreemptivePriority": func(w *bytes.Buffer) ScheduleResult { return PrioritySchedule(w, "t", nil, true) },
+		"EDF":                func(w *bytes.Buffer) ScheduleResult { return EDFSchedule(w, "t", nil) },
+		"RR":                 func(w *bytes.Buffer) ScheduleResult { return RRSchedule(w, "t", 1, []Process{}) },
+	}
+	for name, schedule := range schedulers {
+		schedule := schedule
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			got := schedule(&w)
+			if diff := cmp.Diff(w.String(), "no processes to schedule\n"); diff != "" {
+				t.Errorf(diff)
+			}
+			if strings.Contains(w.String(), "NaN") || got.AveWait != 0 || got.Throughput != 0 {
+				t.Errorf("unexpected averages in %+v", got)
+			}
+		})
+	}
+}
+
+func TestSchedulersLeaveInputUnmodified(t *testing.T) {
+	t.Parallel()
+	// Out of arrival order, with a tie in burst and arrival, so any scheduler sorting its processes in place would show.
+	processes := []Process{
+		{ProcessID: "P2", ArrivalTime: 3, BurstDuration: 2, Priority: 3, Deadline: 9},
+		{ProcessID: "P0", ArrivalTime: 1, BurstDuration: 5, Priority: 1, Bursts: []BurstSegment{
+			{Kind: CPUBurst, Duration: 2}, {Kind: IOBurst, Duration: 3}, {Kind: CPUBurst, Duration: 3},
+		}},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 2, Priority: 2, Deadline: 4},
+		{ProcessID: "P3", ArrivalTime: 0, BurstDuration: 2, Priority: 2},
+	}
+	original := cloneProcesses(processes)
+	schedulers := map[string]func([]Process) ScheduleResult{
+		"FCFS":         func(p []Process) ScheduleResult { return FCFSSchedule(io.Discard, "t", p) },
+		"SJF":          func(p []Process) ScheduleResult { return SJFSchedule(io.Discard, "t", p, WithLookahead()) },
+		"FCFSMulti":    func(p []Process) ScheduleResult { return FCFSScheduleMulti(io.Discard, "t", p, 2) },
+		"SJFPriority":  func(p []Process) ScheduleResult { return SJFPrioritySchedule(io.Discard, "t", p) },
+		"Priority":     func(p []Process) ScheduleResult { return PrioritySchedule(io.Discard, "t", p, true, WithAging(1)) },
+		"HRRN":         func(p []Process) ScheduleResult { return HRRNSchedule(io.Discard, "t", p) },
+		"SRTF":         func(p []Process) ScheduleResult { return SRTFSchedule(io.Discard, "t", p) },
+		"EDF":          func(p []Process) ScheduleResult { return EDFSchedule(io.Discard, "t", p) },
+		"RR":           func(p []Process) ScheduleResult { return RRSchedule(io.Discard, "t", 1, p, WithSwitchCost(1)) },
+		"MLFQ":         func(p []Process) ScheduleResult { return MLFQSchedule(io.Discard, "t", p, []int64{1, 2}) },
+		"Lottery":      func(p []Process) ScheduleResult { return LotterySchedule(io.Discard, "t", p, 1) },
+		"WeightedFair": func(p []Process) ScheduleResult { return WeightedFairSchedule(io.Discard, "t", p) },
+		"IO":           func(p []Process) ScheduleResult { return FCFSIOSchedule(io.Discard, "t", p) },
+		"Gang":         func(p []Process) ScheduleResult { return GangSchedule(io.Discard, "t", p, 2) },
+		"Budget":       func(p []Process) ScheduleResult { return BudgetSchedule(io.Discard, "t", p, nil, 10) },
+	}
+	// The same slice goes to one scheduler after another, as a caller comparing them would pass it.
+	for _, name := range []string{"FCFS", "SJF"} {
+		if got := schedulers[name](processes); len(got.Rows) != len(processes) {
+			t.Fatalf("%s scheduled %d of %d processes", name, len(got.Rows), len(processes))
+		}
+		if diff := cmp.Diff(processes, original); diff != "" {
+			t.Fatalf("%s modified its input: %s", name, diff)
+		}
+	}
+	for name, schedule := range schedulers {
+		name, schedule := name, schedule
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			input := cloneProcesses(original)
+			if got := schedule(input); len(got.Rows) != len(input) {
+				t.Fatalf("scheduled %d of %d processes", len(got.Rows), len(input))
+			}
+			if diff := cmp.Diff(input, original); diff != "" {
+				t.Errorf("input was modified: %s", diff)
+			}
+		})
+	}
+}